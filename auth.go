@@ -0,0 +1,34 @@
+package goroutine
+
+import "net/http"
+
+// WithBearerToken ใส่ header "Authorization: Bearer <token>" ให้ทุก request
+// ถ้า request มี Authorization อยู่แล้ว (จาก Request.Headers หรือ WithHeader) จะไม่ทับค่านั้น
+// ใช้แทน WithBasicAuth ได้ ถ้ากำหนดทั้งสองแบบจะใช้ตัวที่กำหนดทีหลัง
+func WithBearerToken(token string) Option {
+	return func(c *config) {
+		c.auth = func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+}
+
+// WithBasicAuth ใช้ HTTP Basic Auth กับทุก request ผ่าน req.SetBasicAuth
+// ถ้า request มี Authorization อยู่แล้ว (จาก Request.Headers หรือ WithHeader) จะไม่ทับค่านั้น
+// ใช้แทน WithBearerToken ได้ ถ้ากำหนดทั้งสองแบบจะใช้ตัวที่กำหนดทีหลัง
+func WithBasicAuth(username, password string) Option {
+	return func(c *config) {
+		c.auth = func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}
+	}
+}
+
+// applyAuth ใส่ข้อมูลยืนยันตัวตนที่กำหนดผ่าน Option ลงใน req
+// ถูกเรียกหลังใส่ header แล้ว เพื่อให้ Authorization ที่กำหนดไว้ชัดเจนมีลำดับความสำคัญสูงกว่า
+func applyAuth(req *http.Request, cfg config) {
+	if cfg.auth == nil || req.Header.Get("Authorization") != "" {
+		return
+	}
+	cfg.auth(req)
+}
@@ -0,0 +1,52 @@
+package goroutine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// headerServer ตอบกลับด้วยค่า header name ที่ได้รับ
+func headerServer(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get(name)))
+	}))
+}
+
+func TestWithBearerToken(t *testing.T) {
+	server := headerServer("Authorization")
+	defer server.Close()
+
+	results := FetchRequests([]Request{
+		{URL: server.URL},
+		{URL: server.URL, Headers: http.Header{"Authorization": {"Token explicit"}}},
+	}, WithBearerToken("secret"))
+
+	if got := string(results[0].Body); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+	// Authorization ที่กำหนดไว้ใน request ต้องไม่ถูกทับ
+	if got := string(results[1].Body); got != "Token explicit" {
+		t.Errorf("explicit Authorization = %q, want %q", got, "Token explicit")
+	}
+}
+
+func TestWithBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(username + ":" + password))
+	}))
+	defer server.Close()
+
+	result := Fetch(server.URL, WithBasicAuth("alice", "p@ss:word"))
+	if result.Error != nil {
+		t.Fatalf("Fetch error: %v", result.Error)
+	}
+	if got := string(result.Body); got != "alice:p@ss:word" {
+		t.Errorf("basic auth = %q, want %q", got, "alice:p@ss:word")
+	}
+}
@@ -0,0 +1,41 @@
+package goroutine
+
+import (
+	"context"
+	"time"
+)
+
+// FetchInBatches ดึงข้อมูลจาก urls ทีละชุด ชุดละไม่เกิน batchSize URL พร้อมกัน แล้วรอ pause ก่อนเริ่มชุดถัดไป
+// เป็นการชะลอแบบง่ายสำหรับ API ที่จำกัดอัตรา ซึ่งเข้าใจง่ายกว่า WithRateLimit สำหรับบางกรณี
+// ผลลัพธ์เรียงตามลำดับของ urls, WithRetryBudget ใช้ร่วมกันทุกชุด และ batchSize <= 0 หมายถึงส่งทั้งหมดในชุดเดียว
+func FetchInBatches(urls []string, batchSize int, pause time.Duration, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchInBatches(urls, batchSize, pause)
+}
+
+// FetchInBatches ทำงานเหมือนฟังก์ชัน FetchInBatches แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchInBatches(urls []string, batchSize int, pause time.Duration) []APIResult {
+	return f.FetchInBatchesContext(context.Background(), urls, batchSize, pause)
+}
+
+// FetchInBatchesContext ทำงานเหมือน FetchInBatches แต่หยุดเมื่อ ctx ถูกยกเลิก
+// รวมถึงระหว่างรอ pause ซึ่ง URL ของชุดที่เหลือจะได้ error ของ ctx
+func (f *Fetcher) FetchInBatchesContext(ctx context.Context, urls []string, batchSize int, pause time.Duration) []APIResult {
+	if batchSize <= 0 {
+		batchSize = len(urls)
+	}
+	cfg := f.cfg
+	cfg.retryBudget = newRetryBudget(cfg.retryBudgetSize)
+
+	results := make([]APIResult, len(urls))
+	for start := 0; start < len(urls); start += batchSize {
+		if start > 0 {
+			// ถ้า ctx ถูกยกเลิกระหว่างรอ ชุดที่เหลือจะล้มเหลวทันทีด้วย error ของ ctx
+			_ = sleepContext(ctx, pause)
+		}
+		end := min(start+batchSize, len(urls))
+		dispatch(ctx, requestsFromURLs(urls[start:end]), cfg, func(index int, result APIResult) {
+			results[start+index] = result
+		})
+	}
+	return results
+}
@@ -0,0 +1,21 @@
+package goroutine
+
+import (
+	"encoding/hex"
+	"hash"
+)
+
+// WithBodyHash คำนวณ checksum ของ response body ด้วย hash ที่ newHash สร้าง (เช่น sha256.New)
+// แล้วเก็บเป็นเลขฐานสิบหกใน APIResult.BodyHash เพื่อใช้ตรวจว่าเนื้อหาเปลี่ยนไปหรือไม่ระหว่างการดึงแต่ละครั้ง
+// hash ถูกคำนวณไปพร้อมกับการอ่าน body (หลังถอดการบีบอัด) จึงไม่ต้องอ่านซ้ำ
+// และใช้ได้กับ WithBodyWriter ด้วย, BodyHash ว่างถ้าอ่าน body ไม่สำเร็จหรือไม่มี body (เช่น HEAD)
+func WithBodyHash(newHash func() hash.Hash) Option {
+	return func(c *config) {
+		c.bodyHash = newHash
+	}
+}
+
+// hashHex คืนค่า hash ของ h เป็นเลขฐานสิบหก
+func hashHex(h hash.Hash) string {
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,46 @@
+package goroutine
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// FetchReader ดึงข้อมูลจาก url โดยไม่อ่าน body เข้าหน่วยความจำ แต่ส่ง body ของ response ที่ยังไม่ได้อ่าน
+// มาใน APIResult.BodyReader (Body เป็น nil) ให้ส่งต่อให้ parser ที่รับ io.Reader ได้โดยใช้หน่วยความจำคงที่
+// ผู้เรียกเป็นเจ้าของ BodyReader และต้อง Close เสมอ ไม่เช่นนั้น connection จะค้างอยู่และ Shutdown จะรอไม่จบ
+// WithTimeout ครอบคลุมการอ่าน body จนกว่าจะ Close ด้วย, body ถูกถอดการบีบอัดแล้ว
+// BodyReader มีเฉพาะเมื่อ request สำเร็จ ถ้าล้มเหลวจะอ่าน body ของ error เข้า Body ตามปกติ
+// WithBodyWriter, WithBodyHash และ WithMaxBody ไม่มีผลกับ body ที่ส่งผ่าน BodyReader
+// และ FetchReader ส่ง request จริงเสมอโดยไม่ใช้หรือเก็บ WithCache และ WithETagCache
+func FetchReader(url string, opts ...Option) APIResult {
+	return NewFetcher(opts...).FetchReader(url)
+}
+
+// FetchReader ทำงานเหมือนฟังก์ชัน FetchReader แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchReader(url string) APIResult {
+	return f.FetchReaderContext(context.Background(), url)
+}
+
+// FetchReaderContext ทำงานเหมือน FetchReader แต่หยุดเมื่อ ctx ถูกยกเลิก (รวมถึงระหว่างอ่าน BodyReader)
+func (f *Fetcher) FetchReaderContext(ctx context.Context, url string) APIResult {
+	cfg := f.cfg
+	cfg.streamBody = true
+	return fetch(ctx, Request{URL: url}, cfg)
+}
+
+// bodyReadCloser อ่านจาก Reader และเมื่อ Close จะปิด closer แล้วเรียก release หนึ่งครั้ง
+// ใช้คืนทรัพยากรที่ต้องอยู่จนกว่าผู้เรียกจะอ่าน BodyReader เสร็จ (context ของ request, การนับของ Shutdown)
+type bodyReadCloser struct {
+	io.Reader
+	closer  io.Closer
+	release func()
+	once    sync.Once
+}
+
+// Close ปิด body แล้วคืนทรัพยากร การเรียกซ้ำปลอดภัย
+func (b *bodyReadCloser) Close() error {
+	err := b.closer.Close()
+	b.once.Do(b.release)
+	return err
+}
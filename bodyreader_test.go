@@ -0,0 +1,82 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("streamed"))
+	}))
+	defer server.Close()
+
+	result := FetchReader(server.URL)
+	if result.Error != nil || result.BodyReader == nil {
+		t.Fatalf("got error %v, BodyReader %v; want a BodyReader", result.Error, result.BodyReader)
+	}
+	defer result.BodyReader.Close()
+	body, err := io.ReadAll(result.BodyReader)
+	if err != nil || string(body) != "streamed" {
+		t.Errorf("read %q, %v; want %q", body, err, "streamed")
+	}
+}
+
+// FetchReader ไม่คืนผลลัพธ์จาก cache แม้ Fetch ก่อนหน้าจะเก็บ response ของ URL เดียวกันไว้แล้ว
+func TestFetchReaderBypassesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	for name, opt := range map[string]Option{"WithCache": WithCache(time.Minute), "WithETagCache": WithETagCache()} {
+		f := NewFetcher(opt)
+		f.Fetch(server.URL)
+		result := f.FetchReader(server.URL)
+		if result.Error != nil || result.FromCache || result.BodyReader == nil {
+			t.Errorf("%s: got error %v, FromCache %v, BodyReader %v; want a live BodyReader",
+				name, result.Error, result.FromCache, result.BodyReader)
+			continue
+		}
+		body, err := io.ReadAll(result.BodyReader)
+		result.BodyReader.Close()
+		if err != nil || string(body) != "fresh" {
+			t.Errorf("%s: read %q, %v; want %q", name, body, err, "fresh")
+		}
+	}
+}
+
+// response ที่ช้าเกิน WithMaxResponseTime ได้ Error โดยไม่มี BodyReader ค้างอยู่ให้ Shutdown ต้องรอ
+func TestFetchReaderSlowResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte("late"))
+	}))
+	defer server.Close()
+
+	f := NewFetcher(WithMaxResponseTime(10 * time.Millisecond))
+	result := f.FetchReader(server.URL)
+	if !errors.Is(result.Error, ErrSlowResponse) {
+		t.Errorf("error = %v, want ErrSlowResponse", result.Error)
+	}
+	if result.BodyReader != nil {
+		result.BodyReader.Close()
+		t.Error("BodyReader is set on a failed result")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := f.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown error = %v, want nil", err)
+	}
+}
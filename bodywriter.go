@@ -0,0 +1,50 @@
+package goroutine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WithBodyWriter เขียน response body ของ request ที่สำเร็จลง writer ที่ open คืนมา
+// แทนการเก็บไว้ใน APIResult.Body เหมาะกับการดาวน์โหลดไฟล์ขนาดใหญ่
+// Body ของผลลัพธ์จะเป็น nil และจำนวน byte ที่เขียนอยู่ใน BytesWritten
+//
+// open ถูกเรียกหนึ่งครั้งต่อ response ที่สำเร็จ (อาจถูกเรียกพร้อมกันจากหลาย goroutine)
+// ผู้เรียกเป็นผู้ปิด writer เอง, response ที่ status ไม่ถูกต้องยังถูกอ่านเข้า Body ตามปกติ
+// และ error ระหว่างเขียนจะไม่ถูกลองใหม่ เพื่อไม่ให้ข้อมูลถูกเขียนซ้ำลง writer เดิม
+func WithBodyWriter(open func(url string) (io.Writer, error)) Option {
+	return func(c *config) {
+		c.bodyWriter = open
+	}
+}
+
+// writeBody คัดลอก body ลง writer ของ url นี้ แล้วบันทึกจำนวน byte ลงใน result.BytesWritten
+// ถ้ากำหนด maxBodyBytes ไว้ body ต้องถูกจำกัดไว้ที่ maxBodyBytes+1 byte มาก่อนแล้ว
+func writeBody(url string, body io.Reader, result *APIResult, cfg config) error {
+	w, err := cfg.bodyWriter(url)
+	if err != nil {
+		return fmt.Errorf("error opening body writer: %w", err)
+	}
+
+	if cfg.maxBodyBytes <= 0 {
+		result.BytesWritten, err = io.Copy(w, body)
+		if err != nil {
+			return fmt.Errorf("error writing response body: %w", err)
+		}
+		return nil
+	}
+
+	// เขียนไม่เกิน limit แล้วลองอ่านอีก 1 byte เพื่อดูว่า body ใหญ่กว่าที่กำหนดหรือไม่
+	result.BytesWritten, err = io.CopyN(w, body, cfg.maxBodyBytes)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error writing response body: %w", err)
+	}
+	if n, _ := io.ReadFull(body, make([]byte, 1)); n > 0 {
+		return fmt.Errorf("response body exceeds %d bytes", cfg.maxBodyBytes)
+	}
+	return nil
+}
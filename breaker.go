@@ -0,0 +1,142 @@
+package goroutine
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen คือ error ที่ได้เมื่อ request ถูกปฏิเสธทันทีเพราะ circuit breaker ของ host นั้นเปิดอยู่
+// ตรวจได้ด้วย errors.Is(result.Error, ErrCircuitOpen)
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// WithCircuitBreaker หยุดส่ง request ไปยัง host ที่ล้มเหลวติดกัน threshold ครั้ง เป็นเวลา cooldown
+// ระหว่างนั้น request ไปยัง host เดียวกันจะได้ ErrCircuitOpen ทันทีโดยไม่ส่งจริง
+// เมื่อครบ cooldown จะปล่อย request ทดสอบหนึ่งตัว (half-open) ถ้าสำเร็จจะกลับมาส่งตามปกติ
+// ถ้าล้มเหลวจะเปิดต่ออีก cooldown, ความล้มเหลวคือ network error หรือ status ที่ retryOn อนุญาต
+// สถานะแยกตาม host และใช้ร่วมกันทุกการเรียกของ Fetcher เดียวกัน, ค่า threshold <= 0 หมายถึงปิด
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *config) {
+		c.breakerThreshold = threshold
+		c.breakerCooldown = cooldown
+	}
+}
+
+// สถานะของ circuit breaker ของแต่ละ host
+const (
+	circuitClosed   = "closed"    // ส่ง request ตามปกติ
+	circuitOpen     = "open"      // ปฏิเสธ request ทันทีจนกว่าจะครบ cooldown
+	circuitHalfOpen = "half-open" // ปล่อย request ทดสอบได้ครั้งละหนึ่งตัว
+)
+
+// circuitBreaker เก็บสถานะของแต่ละ host ใช้ร่วมกันได้จากหลาย goroutine
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	hosts     map[string]*hostCircuit
+}
+
+// hostCircuit คือสถานะของ host หนึ่งตัว
+type hostCircuit struct {
+	state    string
+	failures int       // จำนวนครั้งที่ล้มเหลวติดกัน
+	openedAt time.Time // เวลาที่เปิด circuit ครั้งล่าสุด
+	probing  bool      // มี request ทดสอบของ half-open กำลังทำงานอยู่
+}
+
+// newCircuitBreaker สร้าง circuitBreaker คืน nil ถ้า threshold <= 0 (ปิดการใช้งาน)
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, hosts: make(map[string]*hostCircuit)}
+}
+
+// circuitHost คืน host ของ rawURL ที่ใช้เป็น key ของสถานะ
+func circuitHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// allow ตรวจว่าส่ง request ไปยัง host ได้หรือไม่ ถ้าไม่ได้จะคืน error ที่ครอบ ErrCircuitOpen
+// พร้อมสถานะของ breaker, ถ้า b เป็น nil จะอนุญาตเสมอ
+func (b *circuitBreaker) allow(host string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := b.hosts[host]
+	if h == nil || h.state == circuitClosed {
+		return nil
+	}
+	if h.state == circuitOpen {
+		if wait := b.cooldown - time.Since(h.openedAt); wait > 0 {
+			return fmt.Errorf("%w for host %q (state: %s, %d consecutive failures, retry in %s)",
+				ErrCircuitOpen, host, h.state, h.failures, wait.Round(time.Millisecond))
+		}
+		// ครบ cooldown แล้ว ปล่อย request นี้เป็นตัวทดสอบ
+		h.state = circuitHalfOpen
+		h.probing = false
+	}
+	if h.probing {
+		return fmt.Errorf("%w for host %q (state: %s, waiting for probe request)", ErrCircuitOpen, host, h.state)
+	}
+	h.probing = true
+	return nil
+}
+
+// record บันทึกผลของ request ที่ allow อนุญาตแล้ว
+// สำเร็จจะปิด circuit และล้างจำนวนครั้งที่ล้มเหลว ล้มเหลวครบ threshold หรือล้มเหลวตอน half-open จะเปิด circuit
+func (b *circuitBreaker) record(host string, failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := b.hosts[host]
+	if h == nil {
+		if !failed {
+			return
+		}
+		h = &hostCircuit{state: circuitClosed}
+		b.hosts[host] = h
+	}
+	if !failed {
+		h.state = circuitClosed
+		h.failures = 0
+		h.probing = false
+		return
+	}
+	h.failures++
+	if h.state == circuitHalfOpen || h.failures >= b.threshold {
+		h.state = circuitOpen
+		h.openedAt = time.Now()
+		h.probing = false
+	}
+}
+
+// release คืนสิทธิ์ของ request ที่ allow อนุญาตแล้วแต่ไม่ได้ผลที่ใช้ตัดสินสถานะ host
+// (เช่น ผู้เรียกยกเลิก ctx) เพื่อให้ request ทดสอบตัวถัดไปของ half-open ส่งได้
+func (b *circuitBreaker) release(host string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if h := b.hosts[host]; h != nil {
+		h.probing = false
+	}
+}
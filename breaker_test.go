@@ -0,0 +1,82 @@
+package goroutine
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// statusServer ตอบ 503 ที่ path /fail และ 200 กับ path อื่น
+func statusServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+}
+
+// request ทดสอบของ half-open ที่ panic นับเป็นความล้มเหลว ไม่ทำให้ host ถูกปฏิเสธตลอดไป
+func TestCircuitBreakerProbePanic(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	server := statusServer()
+	defer server.Close()
+
+	client := &http.Client{Transport: panicTransport{panicPath: "/panic"}}
+	f := NewFetcher(WithClient(client), WithCircuitBreaker(1, cooldown))
+
+	if result := f.Fetch(server.URL + "/fail"); result.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("first request status = %d, want 503", result.StatusCode)
+	}
+	time.Sleep(cooldown)
+	if result := f.Fetch(server.URL + "/panic"); result.Error == nil {
+		t.Fatal("probe request did not fail")
+	}
+	// panic ของ request ทดสอบเปิด circuit อีกรอบ
+	if result := f.Fetch(server.URL); !errors.Is(result.Error, ErrCircuitOpen) {
+		t.Errorf("error after failed probe = %v, want ErrCircuitOpen", result.Error)
+	}
+	time.Sleep(cooldown)
+	if result := f.Fetch(server.URL); result.Error != nil {
+		t.Errorf("error after cooldown = %v, want nil", result.Error)
+	}
+}
+
+// request ทดสอบที่ล้มเหลวก่อนส่ง (เช่น hook คืน error) ไม่ถูกนับว่า host สำเร็จ
+func TestCircuitBreakerProbeNotSent(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	server := statusServer()
+	defer server.Close()
+
+	hookErr := errors.New("no token")
+	f := NewFetcher(
+		WithCircuitBreaker(2, cooldown),
+		WithBeforeRequest(func(req *http.Request) error {
+			if req.URL.Path == "/hook" {
+				return hookErr
+			}
+			return nil
+		}),
+	)
+
+	for range 2 {
+		if result := f.Fetch(server.URL + "/fail"); result.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("request status = %d, want 503", result.StatusCode)
+		}
+	}
+	time.Sleep(cooldown)
+	if result := f.Fetch(server.URL + "/hook"); !errors.Is(result.Error, hookErr) {
+		t.Fatalf("probe error = %v, want hook error", result.Error)
+	}
+	// circuit ยังเป็น half-open และ request ถัดไปเป็นตัวทดสอบแทน ซึ่งล้มเหลวครั้งเดียวก็เปิด circuit อีกรอบ
+	// (ถ้า hook ถูกนับว่าสำเร็จ circuit จะปิดและต้องล้มเหลวครบ threshold ก่อน)
+	if result := f.Fetch(server.URL + "/fail"); result.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("second probe status = %d, error %v; want 503", result.StatusCode, result.Error)
+	}
+	if result := f.Fetch(server.URL); !errors.Is(result.Error, ErrCircuitOpen) {
+		t.Errorf("error after failed probe = %v, want ErrCircuitOpen", result.Error)
+	}
+}
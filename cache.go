@@ -0,0 +1,172 @@
+package goroutine
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// WithETagCache จำ ETag และ Last-Modified ของ response GET ที่สำเร็จไว้ในหน่วยความจำตาม URL
+// แล้วส่ง If-None-Match และ If-Modified-Since ไปกับ request ถัดไปของ URL เดียวกัน
+// ถ้า server ตอบ 304 Not Modified ผลลัพธ์จะมี Body เดิมจาก cache, StatusCode 304 และ FromCache เป็น true
+// โดยไม่ถือว่าเป็น error, cache ใช้ร่วมกันทุกการเรียกของ Fetcher เดียวกัน
+// ถ้า request กำหนด If-None-Match หรือ If-Modified-Since เองจะไม่ใช้ cache กับ request นั้น
+func WithETagCache() Option {
+	return func(c *config) {
+		c.etagCache = true
+	}
+}
+
+// etagEntry คือ response ที่จำไว้ของ URL หนึ่งตัว
+type etagEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// etagCache เก็บ etagEntry ตาม URL ใช้ร่วมกันได้จากหลาย goroutine
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+// newETagCache สร้าง etagCache คืน nil ถ้าไม่ได้เปิดใช้
+func newETagCache(enabled bool) *etagCache {
+	if !enabled {
+		return nil
+	}
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+// prepare ใส่ header เงื่อนไขจาก response ครั้งก่อนลงใน req
+// คืน entry และ true ถ้าใส่แล้ว ซึ่งหมายความว่า 304 ที่ได้ตอบกลับมาใช้ body จาก entry ได้
+func (c *etagCache) prepare(req *http.Request) (etagEntry, bool) {
+	if c == nil || req.Method != http.MethodGet ||
+		req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return etagEntry{}, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[req.URL.String()]
+	c.mu.Unlock()
+	if !ok {
+		return etagEntry{}, false
+	}
+
+	if entry.etag != "" {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	if entry.lastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.lastModified)
+	}
+	return entry, true
+}
+
+// store จำ body ของ response 200 ที่มี ETag หรือ Last-Modified ไว้สำหรับ request ถัดไป
+func (c *etagCache) store(req *http.Request, resp *http.Response, body []byte) {
+	if c == nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return
+	}
+	entry := etagEntry{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+	// คัดลอก body เพื่อไม่ให้การแก้ไข Body ของผลลัพธ์กระทบข้อมูลใน cache
+	entry.body = bytes.Clone(body)
+
+	c.mu.Lock()
+	c.entries[req.URL.String()] = entry
+	c.mu.Unlock()
+}
+
+// WithCache เก็บ response ของ request GET ที่สำเร็จ (ไม่มี Error และ status 2xx) ไว้ในหน่วยความจำเป็นเวลา ttl
+// request ถัดไปของ URL เดียวกันก่อนหมดอายุจะได้ผลลัพธ์จาก cache ทันทีโดยไม่ส่ง request
+// ผลลัพธ์นั้นมี FromCache เป็น true และ Attempts ว่าง (ไม่ได้ส่ง request), cache ใช้ร่วมกันทุกการเรียกของ Fetcher เดียวกัน
+// ไม่ cache response ที่เขียนลง writer ด้วย WithBodyWriter, ค่า ttl <= 0 หมายถึงปิด
+func WithCache(ttl time.Duration) Option {
+	return func(c *config) {
+		c.cacheTTL = ttl
+	}
+}
+
+// cachedResult คือผลลัพธ์ที่เก็บไว้พร้อมเวลาหมดอายุ
+type cachedResult struct {
+	result  APIResult
+	expires time.Time
+}
+
+// responseCache เก็บผลลัพธ์ตาม URL เป็นเวลา ttl ใช้ร่วมกันได้จากหลาย goroutine
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResult
+}
+
+// newResponseCache สร้าง responseCache คืน nil ถ้า ttl <= 0 (ปิดการใช้งาน)
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &responseCache{ttl: ttl, entries: make(map[string]cachedResult)}
+}
+
+// cacheKey คืน URL ที่รวม Request.Query แล้วสำหรับใช้เป็น key ของ cache
+// และ false ถ้า request นี้ cache ไม่ได้ (ไม่ใช่ GET หรือ URL ไม่ถูกต้อง)
+func cacheKey(r Request) (string, bool) {
+	if r.Method != "" && r.Method != http.MethodGet {
+		return "", false
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return "", false
+	}
+	mergeQuery(u, r.Query)
+	return u.String(), true
+}
+
+// get คืนผลลัพธ์ของ key ถ้ายังไม่หมดอายุ โดยคัดลอก Body และ Headers ให้ผู้เรียกแต่ละคน
+func (c *responseCache) get(key string, cacheable bool) (APIResult, bool) {
+	if c == nil || !cacheable {
+		return APIResult{}, false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if !ok {
+		return APIResult{}, false
+	}
+
+	result := entry.result
+	result.Body = bytes.Clone(result.Body)
+	result.Headers = result.Headers.Clone()
+	result.FromCache = true
+	result.Attempts = nil
+	result.StartTime = time.Now()
+	result.EndTime = result.StartTime
+	result.Latency = 0
+	return result, true
+}
+
+// put เก็บ result ไว้ถ้าสำเร็จด้วย status 2xx และไม่ได้มาจาก cache อยู่แล้ว
+func (c *responseCache) put(key string, result APIResult) {
+	if c == nil || result.Error != nil || result.FromCache ||
+		result.StatusCode < 200 || result.StatusCode >= 300 {
+		return
+	}
+	result.Body = bytes.Clone(result.Body)
+	result.Headers = result.Headers.Clone()
+
+	c.mu.Lock()
+	c.entries[key] = cachedResult{result: result, expires: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+}
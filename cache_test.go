@@ -0,0 +1,36 @@
+package goroutine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 304 ที่ได้ Body จาก WithETagCache นับเป็นผลสำเร็จ ไม่เรียก WithErrorCallback และไม่อยู่ใน Failed
+func TestETagCacheRevalidationSucceeds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	var errorCalls int
+	f := NewFetcher(WithETagCache(), WithErrorCallback(func(APIResult) { errorCalls++ }))
+	first := f.Fetch(server.URL)
+	second := f.Fetch(server.URL)
+
+	if !second.FromCache || second.StatusCode != http.StatusNotModified || string(second.Body) != "data" {
+		t.Fatalf("second fetch: FromCache %v, status %d, body %q; want cached 304 with body %q",
+			second.FromCache, second.StatusCode, second.Body, "data")
+	}
+	if errorCalls != 0 {
+		t.Errorf("error callback called %d times, want 0", errorCalls)
+	}
+	if failed := Failed([]APIResult{first, second}); len(failed) != 0 {
+		t.Errorf("Failed returned %d results, want 0", len(failed))
+	}
+}
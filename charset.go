@@ -0,0 +1,70 @@
+package goroutine
+
+import (
+	"encoding/binary"
+	"mime"
+	"strings"
+	"unicode/utf16"
+)
+
+// BodyString คืน Body เป็นข้อความ UTF-8 โดยถอดตาม charset ใน header Content-Type
+// รองรับ utf-8, us-ascii, iso-8859-1 (latin1), windows-1252 และ utf-16 (le/be หรือตาม BOM)
+// ถ้าไม่ได้ระบุ charset หรือเป็น charset ที่ไม่รองรับ จะคืน string(Body) ตรงๆ
+func (r APIResult) BodyString() string {
+	_, params, err := mime.ParseMediaType(r.Headers.Get("Content-Type"))
+	if err != nil {
+		return string(r.Body)
+	}
+	switch strings.ToLower(params["charset"]) {
+	case "iso-8859-1", "latin1", "iso_8859-1", "l1":
+		return decodeSingleByte(r.Body, nil)
+	case "windows-1252", "cp1252":
+		return decodeSingleByte(r.Body, &windows1252)
+	case "utf-16le":
+		return decodeUTF16(r.Body, binary.LittleEndian)
+	case "utf-16be":
+		return decodeUTF16(r.Body, binary.BigEndian)
+	case "utf-16":
+		// ตาม RFC 2781 ใช้ BOM ถ้ามี ไม่เช่นนั้นถือว่าเป็น big-endian
+		if len(r.Body) >= 2 && r.Body[0] == 0xFF && r.Body[1] == 0xFE {
+			return decodeUTF16(r.Body[2:], binary.LittleEndian)
+		}
+		if len(r.Body) >= 2 && r.Body[0] == 0xFE && r.Body[1] == 0xFF {
+			return decodeUTF16(r.Body[2:], binary.BigEndian)
+		}
+		return decodeUTF16(r.Body, binary.BigEndian)
+	default:
+		return string(r.Body)
+	}
+}
+
+// windows1252 คือตัวอักษรของ byte 0x80-0x9F ใน windows-1252 ซึ่งต่างจาก iso-8859-1
+// ตำแหน่งที่ไม่มีตัวอักษรกำหนดไว้ใช้ค่าเดียวกับ iso-8859-1
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+// decodeSingleByte แปลง charset ที่ใช้หนึ่ง byte ต่อตัวอักษร byte แต่ละตัวตรงกับ code point เดียวกัน
+// ยกเว้นช่วง 0x80-0x9F ที่ใช้ค่าจาก high ถ้ากำหนดไว้
+func decodeSingleByte(body []byte, high *[32]rune) string {
+	var sb strings.Builder
+	sb.Grow(len(body))
+	for _, b := range body {
+		if high != nil && b >= 0x80 && b <= 0x9F {
+			sb.WriteRune(high[b-0x80])
+		} else {
+			sb.WriteRune(rune(b))
+		}
+	}
+	return sb.String()
+}
+
+// decodeUTF16 แปลง UTF-16 ตามลำดับ byte ที่กำหนด byte สุดท้ายที่ไม่ครบคู่จะถูกข้าม
+func decodeUTF16(body []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(body)/2)
+	for i := range units {
+		units[i] = order.Uint16(body[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
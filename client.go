@@ -0,0 +1,190 @@
+package goroutine
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// WithNoRedirects ปิดการตาม redirect อัตโนมัติ
+// response 3xx จะถูกคืนมาตรงๆ พร้อม StatusCode และ header Location ใน APIResult
+// และไม่ถือว่าเป็น error
+func WithNoRedirects() Option {
+	return func(c *config) {
+		c.noRedirects = true
+	}
+}
+
+// DefaultMaxRedirects คือจำนวน redirect สูงสุดที่ตามได้เมื่อไม่ได้กำหนด WithMaxRedirects
+// นับเฉพาะ redirect จึงส่ง request ได้รวม 11 ครั้ง ต่างจากนโยบายเริ่มต้นของ Go ที่หยุดเมื่อครบ 10 request (9 redirect)
+const DefaultMaxRedirects = 10
+
+// WithMaxRedirects ตาม redirect ได้ไม่เกิน n ครั้งต่อหนึ่ง request (ค่าเริ่มต้นคือ DefaultMaxRedirects)
+// ถ้าเกินจะได้ Error "too many redirects" พร้อมลำดับ URL ที่ผ่านมา ซึ่งช่วยหา redirect loop
+// ค่า n <= 0 หมายถึงไม่ตาม redirect เลยและถือว่าเป็น error (ต่างจาก WithNoRedirects ที่คืน 3xx ตรงๆ)
+// WithNoRedirects มีลำดับความสำคัญสูงกว่าถ้ากำหนดทั้งสองแบบ
+func WithMaxRedirects(n int) Option {
+	return func(c *config) {
+		c.checkRedirect = limitRedirects(n)
+	}
+}
+
+// limitRedirects คืน CheckRedirect ที่หยุดเมื่อตาม redirect ครบ n ครั้ง
+func limitRedirects(n int) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) <= n {
+			return nil
+		}
+		chain := make([]string, 0, len(via)+1)
+		for _, r := range via {
+			chain = append(chain, r.URL.String())
+		}
+		chain = append(chain, req.URL.String())
+		return fmt.Errorf("too many redirects (max %d): %s", n, strings.Join(chain, " -> "))
+	}
+}
+
+// redirectChain คืน URL ที่ถูก redirect ผ่านก่อนจะได้ req ตามลำดับ (ไม่รวม URL ของ req เอง)
+// โดยไล่ย้อนจาก response ที่ทำให้เกิด redirect แต่ละครั้ง
+func redirectChain(req *http.Request) []string {
+	var chain []string
+	for resp := req.Response; resp != nil; resp = resp.Request.Response {
+		chain = append(chain, resp.Request.URL.String())
+	}
+	slices.Reverse(chain)
+	return chain
+}
+
+// WithProxy ส่งทุก request ผ่าน proxy ที่ proxyURL (เช่น "http://proxy.internal:3128")
+// ถ้า proxyURL ไม่ถูกต้อง ทุก request จะได้ Error ที่บอกสาเหตุแทนการส่งตรงโดยไม่ผ่าน proxy
+func WithProxy(proxyURL string) Option {
+	u, err := url.Parse(proxyURL)
+	proxy := http.ProxyURL(u)
+	if err != nil {
+		proxy = func(*http.Request) (*url.URL, error) {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+		}
+	}
+	return withTransport(func(t *http.Transport) {
+		t.Proxy = proxy
+	})
+}
+
+// WithProxyFromEnvironment ใช้ proxy ตาม environment variable HTTP_PROXY, HTTPS_PROXY และ NO_PROXY
+// (เหมือนค่าเริ่มต้นของ http.DefaultTransport) ใช้เมื่อ WithClient ส่ง transport ที่ไม่ได้ตั้ง proxy มา
+func WithProxyFromEnvironment() Option {
+	return withTransport(func(t *http.Transport) {
+		t.Proxy = http.ProxyFromEnvironment
+	})
+}
+
+// WithForceHTTP2 ให้ transport พยายามใช้ HTTP/2 แม้ transport จะถูกปรับแต่ง (เช่น ผ่าน WithClient หรือ TLS option)
+// ซึ่งปกติทำให้ Go ปิด HTTP/2 อัตโนมัติ การเจรจาทำผ่าน TLS (ALPN) เท่านั้น:
+// URL แบบ https ที่ server รองรับ h2 จะใช้ HTTP/2 และส่งหลาย request พร้อมกันบน connection เดียว
+// ส่วน URL แบบ http หรือ server ที่ไม่รองรับจะใช้ HTTP/1.1 ตามปกติโดยไม่เกิด error
+// ถ้า WithClient ส่ง RoundTripper ที่ไม่ใช่ *http.Transport มา option นี้จะไม่มีผล
+func WithForceHTTP2() Option {
+	return withTransport(func(t *http.Transport) {
+		t.ForceAttemptHTTP2 = true
+	})
+}
+
+// WithCookieJar เก็บ cookie ที่ server ตั้งไว้ใน jar แล้วส่งกลับไปกับ request ถัดไป (เช่น session หลัง login)
+// ใช้ jar ร่วมกันทุกการเรียกของ Fetcher เดียวกัน ถ้าไม่กำหนดจะไม่เก็บ cookie เลย
+// หมายเหตุ: request ที่ส่งพร้อมกันในชุดเดียวกันจะไม่เห็น cookie ของกันและกัน
+// ถ้าต้อง login ก่อนให้เรียก Fetch ของ URL login ให้เสร็จก่อนแล้วจึงเรียก FetchAll
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(c *config) {
+		c.jar = jar
+	}
+}
+
+// WithCookies ทำงานเหมือน WithCookieJar ด้วย jar ใหม่จาก net/http/cookiejar
+func WithCookies() Option {
+	// cookiejar.New คืน error ไม่ได้เมื่อไม่ได้กำหนด Options
+	jar, _ := cookiejar.New(nil)
+	return WithCookieJar(jar)
+}
+
+// WithTransport ใช้ t เป็น transport ของ client โดยตรง (ไม่คัดลอก) เพื่อควบคุมทุกค่าเอง
+// เช่น MaxIdleConnsPerHost, IdleConnTimeout หรือ DisableKeepAlives
+// มีลำดับความสำคัญสูงกว่า transport ของ WithClient และ option ที่ปรับ transport ทีละค่า
+// (WithProxy, WithInsecureSkipVerify, WithRootCAs, WithForceHTTP2 ฯลฯ) ซึ่งจะถูกข้ามทั้งหมด
+// โดย NewFetcher จะส่ง EventWarning ให้ logger ถ้ากำหนดไว้ร่วมกัน
+func WithTransport(t *http.Transport) Option {
+	return func(c *config) {
+		c.transport = t
+	}
+}
+
+// withTransport เพิ่มการปรับค่า transport ที่ buildClient จะใช้ตามลำดับของ Option
+func withTransport(apply func(*http.Transport)) Option {
+	return func(c *config) {
+		c.transportOpts = append(c.transportOpts, apply)
+	}
+}
+
+// defaultClient คือ http.Client ที่ใช้ร่วมกันทุก Fetcher (รวมถึงฟังก์ชันระดับแพ็กเกจอย่าง FetchAll)
+// ที่ไม่ได้กำหนด client และไม่ได้ปรับค่าที่ต้องใช้ client แยก การเรียกแต่ละครั้งจึง reuse connection กันได้
+// http.Client ปลอดภัยสำหรับการใช้งานพร้อมกันจากหลาย goroutine, timeout ยังกำหนดผ่าน context ของแต่ละ request
+var defaultClient = &http.Client{CheckRedirect: limitRedirects(DefaultMaxRedirects)}
+
+// buildClient สร้าง http.Client ที่ Fetcher ใช้จาก cfg
+// ถ้าต้องปรับค่า จะคัดลอก client (ของผู้ใช้หรือ defaultClient) ก่อนเสมอเพื่อไม่แก้ตัวเดิม
+func buildClient(cfg config) *http.Client {
+	client := cfg.client
+	if client == nil {
+		client = defaultClient
+	}
+	if cfg.noRedirects || cfg.checkRedirect != nil || cfg.jar != nil || cfg.transport != nil || len(cfg.transportOpts) > 0 {
+		copied := *client
+		client = &copied
+	}
+
+	if cfg.checkRedirect != nil {
+		client.CheckRedirect = cfg.checkRedirect
+	}
+	if cfg.noRedirects {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	if cfg.jar != nil {
+		client.Jar = cfg.jar
+	}
+	if cfg.transport != nil {
+		client.Transport = cfg.transport
+	} else if len(cfg.transportOpts) > 0 {
+		client.Transport = buildTransport(client.Transport, cfg.transportOpts)
+	}
+	return client
+}
+
+// warnTransport เตือนผ่าน logger เมื่อ option ที่ปรับ transport ถูกข้ามเพราะใช้ WithTransport
+// ถูกเรียกครั้งเดียวใน NewFetcher
+func warnTransport(cfg config) {
+	if cfg.transport != nil && len(cfg.transportOpts) > 0 {
+		cfg.log(EventWarning, APIResult{Error: errors.New("transport options such as WithProxy and TLS settings are ignored because WithTransport is set")})
+	}
+}
+
+// buildTransport คัดลอก base (หรือ http.DefaultTransport ถ้าเป็น nil) แล้วปรับค่าตาม opts
+// ถ้า base ไม่ใช่ *http.Transport (เช่น RoundTripper ที่ผู้ใช้เขียนเอง) จะคืน base โดยไม่ปรับค่า
+func buildTransport(base http.RoundTripper, opts []func(*http.Transport)) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return base
+	}
+	t = t.Clone()
+	for _, apply := range opts {
+		apply(t)
+	}
+	return t
+}
@@ -0,0 +1,83 @@
+package goroutine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// WithProxy ส่ง request ผ่าน proxy ซึ่งได้รับ URL เต็มของปลายทาง
+func TestWithProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied " + r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	// ปลายทางไม่มีอยู่จริง request จึงสำเร็จได้ก็ต่อเมื่อผ่าน proxy เท่านั้น
+	result := Fetch("http://target.invalid/path?q=1", WithProxy(proxy.URL))
+	if result.Error != nil {
+		t.Fatalf("Fetch error: %v", result.Error)
+	}
+	if got, want := string(result.Body), "proxied http://target.invalid/path?q=1"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+// proxy URL ที่ไม่ถูกต้องทำให้ทุก request ได้ Error แทนการส่งตรงโดยไม่ผ่าน proxy
+func TestWithProxyInvalidURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request bypassed the invalid proxy")
+	}))
+	defer server.Close()
+
+	result := Fetch(server.URL, WithProxy("://bad proxy"))
+	if result.Error == nil || !strings.Contains(result.Error.Error(), `invalid proxy URL "://bad proxy"`) {
+		t.Errorf("error = %v, want invalid proxy URL error", result.Error)
+	}
+}
+
+// hopServer redirect จาก /hops/n ไป /hops/n-1 จนถึง /hops/0 ซึ่งตอบ 200
+func hopServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hops/"))
+		if n == 0 {
+			w.Write([]byte("ok"))
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/hops/%d", n-1), http.StatusFound)
+	}))
+}
+
+// WithMaxRedirects(n) ตามได้ครบ n redirect พอดี และได้ error ที่ redirect ครั้งที่ n+1
+func TestWithMaxRedirects(t *testing.T) {
+	server := hopServer()
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		hops    int
+		opts    []Option
+		wantErr bool
+	}{
+		{"three of max three", 3, []Option{WithMaxRedirects(3)}, false},
+		{"four of max three", 4, []Option{WithMaxRedirects(3)}, true},
+		{"one of max zero", 1, []Option{WithMaxRedirects(0)}, true},
+		{"default limit", DefaultMaxRedirects, nil, false},
+		{"one over default limit", DefaultMaxRedirects + 1, nil, true},
+	}
+	for _, tt := range tests {
+		result := Fetch(fmt.Sprintf("%s/hops/%d", server.URL, tt.hops), tt.opts...)
+		if tt.wantErr {
+			if result.Error == nil || !strings.Contains(result.Error.Error(), "too many redirects") {
+				t.Errorf("%s: error = %v, want too many redirects", tt.name, result.Error)
+			}
+			continue
+		}
+		if result.Error != nil || len(result.Redirects) != tt.hops {
+			t.Errorf("%s: got error %v, %d redirects; want %d redirects", tt.name, result.Error, len(result.Redirects), tt.hops)
+		}
+	}
+}
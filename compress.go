@@ -0,0 +1,45 @@
+package goroutine
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+)
+
+// RequestCompressionThreshold คือขนาด body ขั้นต่ำ (byte) ที่ WithRequestCompression จะบีบอัด
+// body ที่เล็กกว่านี้ส่งแบบไม่บีบอัด เพราะ header ของ gzip และเวลาที่ใช้ไม่คุ้มกับขนาดที่ลดลง
+const RequestCompressionThreshold = 1024
+
+// WithRequestCompression บีบอัด body ของ request ที่ใหญ่กว่า RequestCompressionThreshold ด้วย gzip
+// และใส่ header Content-Encoding: gzip เช่น เมื่อ POST ข้อมูล JSON ขนาดใหญ่ บีบอัดครั้งเดียวต่อ URL
+// (การลองใหม่ใช้ body ที่บีบอัดแล้ว) request ที่กำหนด Content-Encoding เองจะไม่ถูกบีบอัดซ้ำ
+// server ต้องรองรับ request ที่บีบอัด ส่วนการจัดการ response ไม่เปลี่ยนแปลง
+func WithRequestCompression() Option {
+	return func(c *config) {
+		c.compressRequests = true
+	}
+}
+
+// compressRequest คืน r ที่ body ถูกบีบอัดด้วย gzip ถ้าเปิด WithRequestCompression และ body ใหญ่พอ
+// Headers ถูกคัดลอกก่อนแก้ไขเพื่อไม่ให้กระทบ Request ของผู้เรียก
+func compressRequest(r Request, cfg config) (Request, error) {
+	if !cfg.compressRequests || len(r.Body) < RequestCompressionThreshold || r.Headers.Get("Content-Encoding") != "" {
+		return r, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(r.Body); err != nil {
+		return r, fmt.Errorf("error compressing request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return r, fmt.Errorf("error compressing request body: %w", err)
+	}
+	r.Body = buf.Bytes()
+	r.Headers = r.Headers.Clone()
+	if r.Headers == nil {
+		r.Headers = make(http.Header)
+	}
+	r.Headers.Set("Content-Encoding", "gzip")
+	return r, nil
+}
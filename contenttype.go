@@ -0,0 +1,29 @@
+package goroutine
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// checkContentType ตรวจว่า media type ของ header Content-Type (got) ตรงกับ want หรือไม่
+// เปรียบเทียบเฉพาะ media type โดยไม่สนตัวพิมพ์และ parameter เช่น "; charset=utf-8"
+func checkContentType(want, got string) error {
+	gotType := mediaType(got)
+	if gotType == mediaType(want) {
+		return nil
+	}
+	if gotType == "" {
+		gotType = "no Content-Type"
+	}
+	return fmt.Errorf("unexpected content type: expected %s, got %s", want, gotType)
+}
+
+// mediaType คืน media type ของ value ในรูปตัวพิมพ์เล็กโดยตัด parameter ออก
+func mediaType(value string) string {
+	if mt, _, err := mime.ParseMediaType(value); err == nil {
+		return mt
+	}
+	mt, _, _ := strings.Cut(value, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
@@ -0,0 +1,21 @@
+package goroutine
+
+import "context"
+
+// WithCorrelationID ดึง correlation ID จาก context ของแต่ละการเรียกด้วย extract
+// แล้วเก็บไว้ใน APIResult.CorrelationID ของทุกผลลัพธ์ เพื่อเชื่อม log ของแพ็กเกจกับ log ของแอปพลิเคชัน
+// ตัวอย่าง: WithCorrelationID(func(ctx context.Context) string { id, _ := ctx.Value(requestIDKey{}).(string); return id })
+// ค่าถูกใส่ก่อนส่งให้ logger, tracer และ metrics จึงเห็นใน EventDone ด้วย ถ้าไม่กำหนด CorrelationID จะเป็นค่าว่าง
+func WithCorrelationID(extract func(ctx context.Context) string) Option {
+	return func(c *config) {
+		c.correlationID = extract
+	}
+}
+
+// correlate คืน correlation ID ของ ctx ตาม WithCorrelationID หรือค่าว่างถ้าไม่ได้กำหนด
+func (c config) correlate(ctx context.Context) string {
+	if c.correlationID == nil {
+		return ""
+	}
+	return c.correlationID(ctx)
+}
@@ -0,0 +1,64 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBatchDeadline คือ error ของ URL ที่ทำงานไม่เสร็จภายในเวลาที่ FetchAllTimeout กำหนด
+// ตรวจได้ด้วย errors.Is(result.Error, ErrBatchDeadline)
+var ErrBatchDeadline = errors.New("batch deadline exceeded")
+
+// FetchAllTimeout ดึงข้อมูลจากทุก URL พร้อมกันแต่รอทั้งชุดไม่เกิน d แล้วคืนผลลัพธ์เท่าที่เสร็จ
+// ต่างจาก WithTimeout ที่จำกัดเวลาของแต่ละ request, URL ที่ยังไม่เสร็จเมื่อครบเวลาจะได้ ErrBatchDeadline
+// และ request ที่ยังทำงานอยู่จะถูกยกเลิก ผลลัพธ์เรียงตามลำดับของ urls เหมือน FetchAll
+// เหมาะกับงานที่ขอข้อมูลเสริมแบบ best-effort ซึ่งรอนานไม่ได้
+func FetchAllTimeout(urls []string, d time.Duration, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchAllTimeout(urls, d)
+}
+
+// FetchAllTimeout ทำงานเหมือนฟังก์ชัน FetchAllTimeout แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchAllTimeout(urls []string, d time.Duration) []APIResult {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	// ผลลัพธ์ที่มาถึงหลังครบเวลาจะถูกทิ้ง เพราะ slice ถูกคืนให้ผู้เรียกไปแล้ว
+	var (
+		mu       sync.Mutex
+		expired  bool
+		finished = make([]bool, len(urls))
+		results  = make([]APIResult, len(urls))
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		dispatch(ctx, requestsFromURLs(urls), f.cfg, func(index int, result APIResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			if !expired {
+				results[index] = result
+				finished[index] = true
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+		return results
+	case <-ctx.Done():
+	}
+
+	// ไม่รอให้ request ที่ถูกยกเลิกคืนผลลัพธ์ (เช่น ค้างอยู่ใน writer) แต่ปิดรับผลลัพธ์ทันที
+	mu.Lock()
+	defer mu.Unlock()
+	expired = true
+	for i, url := range urls {
+		if !finished[i] {
+			results[i] = APIResult{URL: url, Error: fmt.Errorf("%w after %v", ErrBatchDeadline, d)}
+		}
+	}
+	return results
+}
@@ -0,0 +1,50 @@
+package goroutine
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeBody ห่อ body ด้วยตัวถอดการบีบอัดตาม Content-Encoding ใน headers
+// รองรับ gzip และ deflate, encoding อื่นจะคืน body เดิม
+// เมื่อถอดแล้วจะลบ Content-Encoding และ Content-Length ออกจาก headers
+// เพราะไม่ตรงกับ Body ที่ถอดแล้ว (ทำแบบเดียวกับ transport ของ Go)
+func decodeBody(body io.Reader, headers http.Header) (io.Reader, error) {
+	var (
+		decoded io.Reader
+		err     error
+	)
+	switch strings.ToLower(strings.TrimSpace(headers.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		decoded, err = gzip.NewReader(body)
+	case "deflate":
+		// deflate ใน HTTP คือข้อมูลรูปแบบ zlib (RFC 1950)
+		decoded, err = zlib.NewReader(body)
+	default:
+		return body, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	headers.Del("Content-Encoding")
+	headers.Del("Content-Length")
+	return decoded, nil
+}
+
+// hasBody รายงานว่า response อาจมี body หรือไม่ response ของ HEAD, 1xx, 204, 304
+// และ response ที่ Content-Length เป็น 0 ไม่มี body จึงไม่ต้องถอดการบีบอัด
+// แม้ server จะส่ง Content-Encoding มาด้วย
+func hasBody(method string, resp *http.Response) bool {
+	switch {
+	case method == http.MethodHead,
+		resp.StatusCode >= 100 && resp.StatusCode < 200,
+		resp.StatusCode == http.StatusNoContent,
+		resp.StatusCode == http.StatusNotModified,
+		resp.ContentLength == 0:
+		return false
+	}
+	return true
+}
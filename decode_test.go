@@ -0,0 +1,50 @@
+package goroutine
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// gzipServer ตอบ body ที่บีบอัดด้วย gzip พร้อม Content-Encoding: gzip ยกเว้น path /empty ที่ตอบ 204
+// ซึ่งมีเพียง header เหมือน server ที่ใส่ Content-Encoding ให้ทุก response
+func gzipServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		if r.URL.Path == "/empty" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte("hello"))
+		zw.Close()
+		w.Write(buf.Bytes())
+	}))
+}
+
+func TestFetchDecodesGzip(t *testing.T) {
+	server := gzipServer()
+	defer server.Close()
+
+	result := Fetch(server.URL)
+	if result.Error != nil || string(result.Body) != "hello" {
+		t.Errorf("got error %v, body %q; want body %q", result.Error, result.Body, "hello")
+	}
+}
+
+// response ที่ไม่มี body ไม่ถูกถอดการบีบอัด แม้จะมี Content-Encoding
+func TestFetchNoBodyWithContentEncoding(t *testing.T) {
+	server := gzipServer()
+	defer server.Close()
+
+	if result := FetchHead([]string{server.URL})[0]; result.Error != nil {
+		t.Errorf("FetchHead error = %v, want nil", result.Error)
+	}
+	result := Fetch(server.URL + "/empty")
+	if result.Error != nil || result.StatusCode != http.StatusNoContent || len(result.Body) != 0 {
+		t.Errorf("204 response: error %v, status %d, body %q; want empty 204", result.Error, result.StatusCode, result.Body)
+	}
+}
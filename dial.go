@@ -0,0 +1,57 @@
+package goroutine
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WithDialOverride ให้การเชื่อมต่อไปยัง host เปลี่ยนไปเชื่อมต่อที่ addr แทน โดยไม่ต้องแก้ /etc/hosts
+// เช่น ทดสอบ staging, blue/green หรือ canary โดย header Host และ TLS SNI ยังเป็นชื่อ host เดิม
+// host เป็นชื่อ host ("api.example.com") ซึ่งตรงกับทุก port หรือ "host:port" เพื่อระบุ port ที่ต้องตรงด้วย
+// addr เป็น IP หรือชื่อ host ที่จะเชื่อมต่อจริง ถ้าไม่ระบุ port จะใช้ port เดิมของ request
+// กำหนดได้หลายตัวสำหรับหลาย host, เมื่อใช้ proxy จะมีผลกับการเชื่อมต่อไปยัง proxy เท่านั้น
+func WithDialOverride(host, addr string) Option {
+	return withTransport(func(t *http.Transport) {
+		dial := t.DialContext
+		if dial == nil {
+			dial = (&net.Dialer{}).DialContext
+		}
+		t.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			return dial(ctx, network, overrideAddr(address, host, addr))
+		}
+	})
+}
+
+// overrideAddr คืน address ที่ใช้เชื่อมต่อจริงตาม WithDialOverride(host, addr)
+// ถ้า address ไม่ตรงกับ host จะคืน address เดิม
+func overrideAddr(address, host, addr string) string {
+	hostname, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if !strings.EqualFold(host, hostname) && !strings.EqualFold(host, address) {
+		return address
+	}
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), port)
+}
+
+// WithLocalAddr ให้ทุกการเชื่อมต่อออกจาก addr (เช่น &net.TCPAddr{IP: net.ParseIP("10.0.0.2")})
+// สำหรับเครื่องที่มีหลาย network interface และ API ของคู่ค้าที่อนุญาตเฉพาะ IP ต้นทางที่ลงทะเบียนไว้
+// option นี้แทนที่ dialer ของ transport ด้วย net.Dialer ที่ตั้งค่าเหมือน http.DefaultTransport
+// (DialContext เดิมของ transport จาก WithClient จึงไม่ถูกใช้) และใช้ร่วมกับ WithDialOverride ได้ไม่ว่าจะกำหนดลำดับใด
+func WithLocalAddr(addr net.Addr) Option {
+	return func(c *config) {
+		// ใส่ไว้ก่อนการปรับ transport อื่นทั้งหมด เพื่อให้ WithDialOverride ห่อ dialer นี้ ไม่ถูกแทนที่
+		apply := func(t *http.Transport) {
+			dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, LocalAddr: addr}
+			t.DialContext = dialer.DialContext
+		}
+		c.transportOpts = append([]func(*http.Transport){apply}, c.transportOpts...)
+	}
+}
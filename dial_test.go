@@ -0,0 +1,32 @@
+package goroutine
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// WithLocalAddr ให้การเชื่อมต่อออกจาก address ที่กำหนด ซึ่ง server เห็นเป็น address ต้นทาง
+func TestWithLocalAddr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, _ := net.SplitHostPort(r.RemoteAddr)
+		w.Write([]byte(host))
+	}))
+	defer server.Close()
+
+	// ทุก address ใน 127.0.0.0/8 เป็น loopback จึงใช้แยกต้นทางได้โดยไม่ต้องมีหลาย interface
+	// (เป็นจริงบน Linux แต่บางระบบเช่น macOS มีเพียง 127.0.0.1)
+	if l, err := net.Listen("tcp", "127.0.0.2:0"); err != nil {
+		t.Skipf("127.0.0.2 is not available: %v", err)
+	} else {
+		l.Close()
+	}
+	result := Fetch(server.URL, WithLocalAddr(&net.TCPAddr{IP: net.ParseIP("127.0.0.2")}))
+	if result.Error != nil {
+		t.Fatalf("Fetch error: %v", result.Error)
+	}
+	if got := string(result.Body); got != "127.0.0.2" {
+		t.Errorf("server saw source address %q, want %q", got, "127.0.0.2")
+	}
+}
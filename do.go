@@ -0,0 +1,30 @@
+package goroutine
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Do ส่ง req ที่ผู้เรียกสร้างเองโดยใช้การลองใหม่, timeout, header และ hook ของ f
+// เพื่อย้ายโค้ดที่ใช้ http.Client มาใช้ทีละจุดได้ด้วยการเปลี่ยน client.Do เป็น fetcher.Do
+// ใช้ context ของ req (ยกเลิกหรือ deadline ของ req จึงมีผล) และผลลัพธ์มี Body, StatusCode และ Latency ตามปกติ
+// body ของ req ถูกอ่านเข้าหน่วยความจำและปิดก่อนส่งเพื่อให้ส่งซ้ำได้ทุกครั้งที่ลองใหม่
+// header ของ req แทนที่ header ของ Fetcher ที่ key เดียวกัน เหมือน Request.Headers
+// หมายเหตุ: req.Host ที่กำหนดแยกจาก URL จะไม่ถูกใช้ ให้ใส่ header Host ผ่าน WithBeforeRequest แทน
+func (f *Fetcher) Do(req *http.Request) APIResult {
+	r := Request{
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Headers: req.Header.Clone(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return APIResult{URL: r.URL, Error: fmt.Errorf("error reading request body: %w", err)}
+		}
+		r.Body = body
+	}
+	return fetch(req.Context(), r, f.cfg)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+
+	goroutine "github.com/witchakornb/go-routine"
+)
+
+func main() {
+	// --- กำหนดค่าเริ่มต้น ---
+	// URL ของ API ที่ต้องการดึง (ใช้ API ตัวอย่าง)
+	urls := []string{
+		"https://httpbin.org/get?source=api1", // API ตัวอย่างที่ตอบกลับเป็น JSON เกี่ยวกับ request ที่ส่งไป
+		"https://httpbin.org/delay/1",         // API ตัวอย่างที่จะหน่วงเวลา 1 วินาทีก่อนตอบกลับ
+	}
+
+	// --- เริ่มการทำงานพร้อมกัน ---
+	fmt.Println("เริ่มต้นดึงข้อมูลจาก API พร้อมกัน...")
+
+	// FetchAll จะรอจนกว่า goroutine ทั้งหมดทำงานเสร็จแล้วจึงคืนผลลัพธ์
+	// err ไม่เป็น nil ก็ต่อเมื่อทุก request ล้มเหลว
+	results, err := goroutine.FetchAll(urls)
+	if err != nil {
+		fmt.Printf("ดึงข้อมูลไม่สำเร็จเลยสักรายการ: %v\n", err)
+	}
+
+	// --- ประมวลผลผลลัพธ์ ---
+	for _, result := range results {
+		fmt.Printf("\nได้รับผลลัพธ์จาก: %s (ใช้เวลา: %v)\n", result.URL, result.Latency)
+		if result.Error != nil {
+			// ถ้ามี error เกิดขึ้น
+			fmt.Printf("เกิดข้อผิดพลาด: %v\n", result.Error)
+		} else {
+			// ถ้าสำเร็จ พิมพ์ข้อมูลที่ได้
+			// ในการใช้งานจริง อาจจะทำการ unmarshal JSON หรือประมวลผลอื่นๆ
+			fmt.Printf("ข้อมูลที่ได้รับ (ขนาด %d bytes): %s\n", len(result.Body), string(result.Body))
+			// หมายเหตุ: การแปลง []byte เป็น string โดยตรงอาจจะไม่เหมาะกับข้อมูลขนาดใหญ่มาก
+		}
+	}
+
+	fmt.Println("\nประมวลผลผลลัพธ์ทั้งหมดเรียบร้อย")
+}
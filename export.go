@@ -0,0 +1,84 @@
+package goroutine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// resultLine คือข้อมูลสรุปของผลลัพธ์หนึ่งตัวที่ WriteResults เขียนต่อหนึ่งบรรทัด
+type resultLine struct {
+	URL        string  `json:"url"`
+	StatusCode int     `json:"status_code"`
+	LatencyMs  float64 `json:"latency_ms"`
+	Error      *string `json:"error"`
+	BodyBytes  int64   `json:"body_bytes"`
+}
+
+// WriteResults เขียน results ลง w ในรูปแบบ NDJSON (JSON หนึ่ง object ต่อหนึ่งบรรทัด)
+// แต่ละบรรทัดมี url, status_code, latency_ms, error (ข้อความหรือ null) และ body_bytes
+// เขียนทีละบรรทัดโดยไม่สร้างข้อมูลทั้งหมดไว้ในหน่วยความจำก่อน
+func WriteResults(w io.Writer, results []APIResult) error {
+	enc := json.NewEncoder(w)
+	for _, result := range results {
+		line := resultLine{
+			URL:        result.URL,
+			StatusCode: result.StatusCode,
+			LatencyMs:  milliseconds(result.Latency),
+			BodyBytes:  bodySize(result),
+		}
+		if result.Error != nil {
+			msg := result.Error.Error()
+			line.Error = &msg
+		}
+		// Encode เขียน newline ต่อท้ายให้แล้ว
+		if err := enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCSV เขียน results ลง w เป็น CSV ที่มีแถวหัวตาราง
+// URL, StatusCode, LatencyMs, Error, BodySize แล้วตามด้วยหนึ่งแถวต่อหนึ่งผลลัพธ์
+// คอลัมน์ Error เป็นข้อความของ error หรือว่างถ้าสำเร็จ
+func WriteCSV(w io.Writer, results []APIResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"URL", "StatusCode", "LatencyMs", "Error", "BodySize"}); err != nil {
+		return err
+	}
+	for _, result := range results {
+		var msg string
+		if result.Error != nil {
+			msg = result.Error.Error()
+		}
+		row := []string{
+			result.URL,
+			strconv.Itoa(result.StatusCode),
+			strconv.FormatFloat(milliseconds(result.Latency), 'f', -1, 64),
+			msg,
+			strconv.FormatInt(bodySize(result), 10),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// bodySize คืนขนาดของ body ของ result
+// ใช้จำนวน byte ที่เขียนลง writer แทนเมื่อใช้ WithBodyWriter
+func bodySize(result APIResult) int64 {
+	if result.Body == nil && result.BytesWritten > 0 {
+		return result.BytesWritten
+	}
+	return int64(len(result.Body))
+}
+
+// milliseconds แปลง d เป็นจำนวนมิลลิวินาที (มีทศนิยม)
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,41 @@
+package goroutine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WithFailFast ให้ FetchAll และ FetchAllContext ยกเลิก request ที่เหลือทั้งหมดทันทีที่ URL ใดล้มเหลว
+// สำหรับงานแบบได้ทั้งหมดหรือไม่ได้เลย ซึ่งความล้มเหลวเดียวทำให้ทั้งชุดใช้ไม่ได้
+// error ที่คืนมาคือ error ของ URL แรกที่ล้มเหลว (ขึ้นต้นด้วย URL ของมันเหมือน Errors) แทนกฎ "ทุก request ล้มเหลว"
+// ผลลัพธ์ที่เสร็จก่อนหน้ายังอยู่ครบ ส่วน request ที่ถูกยกเลิกหรือยังไม่เริ่มมี Error เป็น context.Canceled
+func WithFailFast() Option {
+	return func(c *config) {
+		c.failFast = true
+	}
+}
+
+// fetchFailFast ส่ง reqs พร้อมกันเหมือน FetchRequestsContext แต่ยกเลิกทั้งชุดเมื่อได้ผลลัพธ์ที่มี Error ตัวแรก
+// และคืน error ของผลลัพธ์ตัวนั้น (nil ถ้าทุก request สำเร็จ)
+func (f *Fetcher) fetchFailFast(ctx context.Context, reqs []Request) ([]APIResult, error) {
+	// request ที่ถูกยกเลิกเพราะ URL อื่นล้มเหลวไม่ถูกรายงานซ้ำโดย WithErrorCallback
+	ctx, cancel := withCancelNotNeeded(ctx)
+	defer cancel()
+
+	var (
+		once  sync.Once
+		first error
+	)
+	results := make([]APIResult, len(reqs))
+	dispatch(ctx, reqs, f.cfg, func(index int, result APIResult) {
+		results[index] = result
+		if result.Error != nil {
+			once.Do(func() {
+				first = fmt.Errorf("%s: %w", result.URL, result.Error)
+				cancel()
+			})
+		}
+	})
+	return results, first
+}
@@ -0,0 +1,43 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// WithFailFast ยกเลิก request ที่เหลือเมื่อ URL แรกล้มเหลว และ WithErrorCallback รายงานเฉพาะ URL ที่ล้มเหลวจริง
+func TestFailFastCancelsRemaining(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer server.Close()
+
+	var errs errorRecorder
+	f := NewFetcher(WithFailFast(), WithErrorCallback(errs.record))
+	start := time.Now()
+	results, err := f.FetchAll([]string{server.URL + "/slow", server.URL + "/fail"})
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("FetchAll took %v, want the slow request cancelled", elapsed)
+	}
+	if err == nil || results[1].StatusCode != http.StatusBadRequest {
+		t.Fatalf("got error %v, status %d; want the 400 failure", err, results[1].StatusCode)
+	}
+	if !errors.Is(results[0].Error, context.Canceled) {
+		t.Errorf("cancelled request error = %v, want context.Canceled", results[0].Error)
+	}
+	if n := errs.count(); n != 1 || errs.results[0].StatusCode != http.StatusBadRequest {
+		t.Errorf("error callback called %d times, want once for the 400", n)
+	}
+}
@@ -0,0 +1,540 @@
+// Package goroutine ดึงข้อมูลจาก API หลายตัวพร้อมกันด้วย goroutine และ channel
+package goroutine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// โครงสร้างสำหรับเก็บผลลัพธ์จาก API แต่ละตัว
+// อาจจะเก็บข้อมูลที่ parse แล้ว หรือ เก็บ error ที่เกิดขึ้น
+type APIResult struct {
+	URL           string
+	FinalURL      string      // URL ที่ตอบ response จริงหลังตาม redirect (เท่ากับ URL ถ้าไม่มี redirect)
+	Redirects     []string    // URL ที่ถูก redirect ผ่านก่อนถึง FinalURL ตามลำดับ (nil ถ้าไม่มี redirect)
+	StatusCode    int         // status code จาก response (0 ถ้าไม่ได้รับ response เลย)
+	Headers       http.Header // header ของ response (nil ถ้าไม่ได้รับ response เลย)
+	Body          []byte
+	BytesWritten  int64         // จำนวน byte ของ body ที่เขียนลง writer เมื่อใช้ WithBodyWriter (Body จะเป็น nil)
+	BodyHash      string        // checksum ของ body เป็นเลขฐานสิบหกเมื่อใช้ WithBodyHash
+	Error         error         // nil เมื่อสำเร็จ คือได้ status 2xx (หรือ status ใดก็ได้เมื่อใช้ WithSkipStatusCheck)
+	Latency       time.Duration // เก็บเวลาที่ใช้ในการดึงข้อมูล เท่ากับ EndTime.Sub(StartTime)
+	StartTime     time.Time     // เวลาที่เริ่มส่ง request (ของความพยายามครั้งสุดท้าย)
+	EndTime       time.Time     // เวลาที่อ่าน body เสร็จ หรือเวลาที่เกิด error
+	Attempts      []Attempt     // ผลของแต่ละครั้งที่ส่ง request ตามลำดับ len(Attempts) คือจำนวนครั้งที่ส่ง (รวมครั้งแรก)
+	FromCache     bool          // true ถ้า Body มาจาก cache ของ Fetcher แทน response body
+	Timing        Timing        // เวลาของแต่ละช่วงใน request เมื่อใช้ WithDetailedTiming (ค่าว่างถ้าไม่ได้ใช้)
+	CorrelationID string        // correlation ID จาก context ของการเรียกเมื่อใช้ WithCorrelationID
+	BodyReader    io.ReadCloser // body ของ response ที่ยังไม่ได้อ่านเมื่อใช้ FetchReader ผู้เรียกต้อง Close เสมอ (Body จะเป็น nil)
+}
+
+// Attempt คือผลของการส่ง request หนึ่งครั้ง ใช้ดูว่าแต่ละครั้งที่ลองใหม่ได้ผลอย่างไร
+// field ระดับบนของ APIResult (StatusCode, Error, Latency) เป็นค่าของ Attempt ตัวสุดท้าย
+// เว้นแต่ถูกยกเลิกระหว่างรอลองใหม่ ซึ่ง APIResult.Error จะเป็น error ของ context แทน
+type Attempt struct {
+	StatusCode int   // status code ของครั้งนี้ (0 ถ้าไม่ได้รับ response)
+	Error      error // error ของครั้งนี้ (nil ถ้าสำเร็จ)
+	Latency    time.Duration
+}
+
+// Retried รายงานว่าผลลัพธ์นี้ได้มาหลังการลองใหม่หรือไม่ (ส่ง request มากกว่าหนึ่งครั้ง)
+// ใช้ร่วมกับ Successful เพื่อหา endpoint ที่ไม่เสถียรซึ่งสำเร็จได้เพราะลองใหม่
+func (r APIResult) Retried() bool {
+	return len(r.Attempts) > 1
+}
+
+// Request อธิบาย HTTP request หนึ่งตัวที่ต้องการส่ง
+type Request struct {
+	Method  string // HTTP method เช่น "POST", ค่าว่างหมายถึง "GET"
+	URL     string
+	Body    []byte      // body ของ request (nil ถ้าไม่มี), ถูกส่งใหม่ทุกครั้งที่ลองใหม่
+	Headers http.Header // header ที่ส่งไปกับ request นี้ เช่น Authorization หรือ X-*
+
+	// Timeout ของ request นี้แต่ละครั้งที่ส่ง ใช้แทน WithTimeout โดยไม่ต้องสร้าง client แยก
+	// ค่า 0 หมายถึงใช้ timeout ของ Fetcher
+	Timeout time.Duration
+
+	// RetryOn ตัดสินว่า status code ใดของ request นี้ควรลองใหม่ (ใช้แทน WithRetryOn)
+	// ถ้าเป็น nil จะใช้ค่าจาก WithRetryOn หรือ DefaultRetryOn
+	RetryOn func(statusCode int) bool
+
+	// Query คือ query parameter ที่จะ encode ต่อท้าย URL อย่างปลอดภัย (escape ให้อัตโนมัติ)
+	// รวมกับ query string เดิมใน URL โดย key ที่ซ้ำกันจะใช้ค่าจาก Query แทน
+	Query url.Values
+
+	// ExpectContentType คือ media type ที่คาดว่าจะได้ (เช่น "application/json") ค่าว่างหมายถึงไม่ตรวจ
+	// ถ้า Content-Type ของ response ที่สำเร็จไม่ตรงกัน จะได้ Error ทันทีโดยไม่อ่าน body
+	// เช่น เมื่อได้หน้า login ที่เป็น HTML แทน JSON
+	ExpectContentType string
+
+	// MinBodySize คือขนาด body ขั้นต่ำ (byte) ของ response 2xx ค่า 0 หมายถึงไม่ตรวจ
+	// body ที่เล็กกว่านี้ (เช่น 200 ที่ body ว่างจาก endpoint ที่ไม่เสถียร) จะได้ Error และลองใหม่ตาม WithRetries
+	// เมื่อใช้ WithSkipStatusCheck จะตรวจเฉพาะ 2xx เช่นกัน 404 ที่ body ว่างจึงคืนตามปกติโดยไม่ลองใหม่
+	// ไม่ตรวจ response ของ HEAD และ body ที่เขียนลง WithBodyWriter หรือส่งผ่าน FetchReader
+	MinBodySize int64
+}
+
+// ฟังก์ชันสำหรับดึงข้อมูลจาก API เดียวใน goroutine
+// รับ Request พร้อมตำแหน่ง index ของมัน, config, WaitGroup สำหรับจัดการ goroutine,
+// และ deliver สำหรับส่งผลลัพธ์กลับ (เช่น เขียนลง slice หรือส่งเข้า channel)
+func fetchAPI(ctx context.Context, index int, r Request, cfg config, wg *sync.WaitGroup, deliver func(int, APIResult)) {
+	// defer wg.Done() จะถูกเรียกเมื่อฟังก์ชันนี้ทำงานเสร็จสิ้น
+	// เพื่อบอก WaitGroup ว่า goroutine นี้ทำงานเสร็จแล้ว
+	defer wg.Done()
+
+	deliver(index, fetch(ctx, r, cfg))
+}
+
+// applyHeaders ใส่ header ที่กำหนดให้ทุก request ก่อน แล้วจึงใส่ headers ของ request นี้
+// ซึ่งแทนที่ค่าเดิมของ key เดียวกัน ตามด้วย User-Agent และ auth ถ้ายังไม่ได้กำหนดไว้
+func applyHeaders(req *http.Request, headers http.Header, cfg config) {
+	for key, values := range cfg.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	for key, values := range headers {
+		req.Header.Del(key)
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if cfg.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", cfg.userAgent)
+	}
+	applyAuth(req, cfg)
+}
+
+// fetch ส่ง request ซ้ำจนกว่าจะสำเร็จ, เจอ error ที่ไม่ควรลองใหม่ หรือครบจำนวนครั้ง
+// ผลลัพธ์ที่คืนคือผลของความพยายามครั้งสุดท้าย
+func fetch(ctx context.Context, r Request, cfg config) (result APIResult) {
+	// ไม่เริ่ม request ใหม่หลังจาก Shutdown และให้ Shutdown รอหรือยกเลิก request นี้ได้
+	ctx, done, err := cfg.lifecycle.enter(ctx)
+	if err != nil {
+		return APIResult{URL: r.URL, Error: err, CorrelationID: cfg.correlate(ctx)}
+	}
+	defer func() {
+		// request ยังไม่จบจนกว่าผู้เรียกจะปิด BodyReader ซึ่ง Shutdown ต้องรอด้วย
+		if result.BodyReader == nil {
+			done()
+			return
+		}
+		result.BodyReader = &bodyReadCloser{Reader: result.BodyReader, closer: result.BodyReader, release: done}
+	}()
+
+	cfg.log(EventStart, APIResult{URL: r.URL})
+	ctx, span := cfg.tracer.Start(ctx, r.URL)
+
+	// probing เป็น true ระหว่างที่ breaker อนุญาต request แล้วแต่ยังไม่ได้บันทึกผล
+	host := circuitHost(r.URL)
+	var probing bool
+
+	// ถ้าเกิด panic ระหว่างทำงาน (เช่น จาก transport ที่ผู้ใช้กำหนดเอง) ให้แปลงเป็น error
+	// ของ URL นี้แทน เพื่อไม่ให้ URL เดียวทำให้ทั้งโปรแกรมล่ม
+	defer func() {
+		if p := recover(); p != nil {
+			result = APIResult{URL: r.URL, Error: fmt.Errorf("panic while fetching: %v", p), Attempts: result.Attempts}
+			// panic นับเป็นความล้มเหลวของ host ไม่เช่นนั้น request ทดสอบของ half-open จะค้างตลอดไป
+			if probing {
+				cfg.breaker.record(host, true)
+			}
+		}
+		result.CorrelationID = cfg.correlate(ctx)
+		span.End(result)
+		cfg.observe(result)
+		cfg.log(EventDone, result)
+		cfg.reportError(ctx, result)
+	}()
+
+	// ถ้าเปิด WithCache และมี response ที่ยังไม่หมดอายุ ให้คืนจาก cache โดยไม่ส่ง request
+	// FetchReader ต้องได้ BodyReader ของ response จริงเสมอ จึงไม่อ่านหรือเก็บ cache
+	key, cacheable := cacheKey(r)
+	cacheable = cacheable && !cfg.streamBody
+	if cached, ok := cfg.cache.get(key, cacheable); ok {
+		return cached
+	}
+	// การเรียก fetch โดยตรง (เช่น Fetch) ไม่ผ่าน dispatch จึงได้ budget ของตัวเอง
+	if cfg.retryBudget == nil {
+		cfg.retryBudget = newRetryBudget(cfg.retryBudgetSize)
+	}
+	// บีบอัด body ครั้งเดียวก่อนส่ง ทุกครั้งที่ลองใหม่จึงใช้ body เดียวกัน
+	if r, err = compressRequest(r, cfg); err != nil {
+		result.URL = r.URL
+		result.Error = err
+		return result
+	}
+	for attempt := 1; ; attempt++ {
+		// รอคิวจาก rate limiter ก่อนส่ง request ทุกครั้ง และไม่เริ่ม request ใหม่ถ้า ctx หมดเวลาแล้ว
+		// ถ้า ctx ถูกยกเลิกระหว่างรอ ให้คงผลของครั้งก่อนหน้า (ถ้ามี) ไว้พร้อม error
+		if err := cfg.limiter.wait(ctx); err != nil {
+			result.URL = r.URL
+			result.Error = err
+			break
+		}
+		// ไม่ส่ง request ไปยัง host ที่ circuit breaker เปิดอยู่ ให้ล้มเหลวทันทีแทนการรอ timeout
+		if err := cfg.breaker.allow(host); err != nil {
+			result.URL = r.URL
+			result.Error = err
+			break
+		}
+		probing = true
+
+		attempts := result.Attempts
+		var retryable, sent bool
+		result, retryable, sent = fetchOnce(ctx, r, cfg)
+		result.Attempts = append(attempts, Attempt{StatusCode: result.StatusCode, Error: result.Error, Latency: result.Latency})
+		// ความล้มเหลวที่ควรลองใหม่บ่งบอกว่า host มีปัญหา แต่การที่ผู้เรียกยกเลิก ctx
+		// หรือ request ที่ไม่ได้ส่งออกไป (เช่น hook หรือ signer คืน error) ไม่ใช่ความผิดของ host
+		if ctx.Err() != nil || !sent {
+			cfg.breaker.release(host)
+		} else {
+			cfg.breaker.record(host, retryable)
+		}
+		probing = false
+		if !retryable || attempt >= cfg.maxAttempts {
+			break
+		}
+		if !cfg.retryBudget.take() {
+			cfg.log(EventRetryBudgetExhausted, result)
+			break
+		}
+		// รอตาม Retry-After หรือเวลา backoff ก่อนลองใหม่ ถ้า ctx ถูกยกเลิกระหว่างรอให้หยุดทันที
+		cfg.log(EventRetry, result)
+		delay := retryDelay(attempt, result, cfg)
+		if cfg.onRetry != nil {
+			cfg.onRetry(r.URL, attempt+1, result.Error, delay)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			result.Error = err
+			break
+		}
+	}
+	// เก็บผลลัพธ์ที่สำเร็จไว้ใน cache (body ที่เขียนลง writer หรือส่งผ่าน BodyReader ไม่มีใน Body จึงเก็บไม่ได้)
+	if cacheable && cfg.bodyWriter == nil {
+		cfg.cache.put(key, result)
+	}
+	return result
+}
+
+// fetchOnce ส่ง request หนึ่งครั้ง แล้วคืนผลลัพธ์พร้อมบอกว่าควรลองใหม่หรือไม่
+// และ request ถูกส่งไปยัง host แล้วหรือไม่ (false ถ้าล้มเหลวตั้งแต่ก่อนส่ง)
+// จะลองใหม่เมื่อเกิด network error หรือเมื่อ status ผ่านเงื่อนไขของ retryOn
+func fetchOnce(ctx context.Context, r Request, cfg config) (APIResult, bool, bool) {
+	// ตั้ง timeout ผ่าน context ของ request นี้ เพื่อป้องกันการรอคอยนานเกินไป
+	// Request.Timeout มีลำดับความสำคัญสูงกว่า timeout ของ Fetcher
+	timeout := cfg.timeout
+	if r.Timeout > 0 {
+		timeout = r.Timeout
+	}
+	cancel := context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	result := APIResult{URL: r.URL, StartTime: time.Now()} // เริ่มจับเวลา
+	var trace *timingTrace
+	if cfg.detailedTiming {
+		trace = &timingTrace{}
+		ctx = trace.withTrace(ctx, result.StartTime)
+	}
+	// ถ้าส่ง body ให้ผู้เรียกผ่าน BodyReader จะยกเลิก context เมื่อผู้เรียก Close แทน
+	defer func() {
+		if result.BodyReader == nil {
+			cancel()
+		}
+	}()
+
+	// finish หยุดจับเวลาและใส่ error ให้ผลลัพธ์ ใช้กับทุกทางออกของฟังก์ชัน
+	// Latency จึงเท่ากับ EndTime.Sub(StartTime) เสมอ
+	var sent bool
+	finish := func(err error, retryable bool) (APIResult, bool, bool) {
+		result.EndTime = time.Now()
+		result.Latency = result.EndTime.Sub(result.StartTime)
+		result.Timing = trace.result()
+		if err == nil && cfg.maxResponseTime > 0 && result.Latency > cfg.maxResponseTime {
+			err = fmt.Errorf("%w: took %v, limit %v", ErrSlowResponse, result.Latency, cfg.maxResponseTime)
+		}
+		result.Error = err
+		// ผลลัพธ์ที่มี Error ไม่ส่ง BodyReader ให้ผู้เรียก จึงต้องปิดเองเพื่อคืน connection และ context
+		if err != nil && result.BodyReader != nil {
+			result.BodyReader.Close()
+			result.BodyReader = nil
+		}
+		return result, retryable, sent
+	}
+
+	// สร้าง HTTP request (ใช้ GET ถ้าไม่ได้กำหนด method)
+	method := r.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	var reqBody io.Reader
+	if r.Body != nil {
+		reqBody = bytes.NewReader(r.Body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, r.URL, reqBody)
+	if err != nil {
+		return finish(fmt.Errorf("error creating request: %w", err), false)
+	}
+	mergeQuery(req.URL, r.Query)
+	applyHeaders(req, r.Headers, cfg)
+	cfg.tracer.Inject(ctx, req.Header)
+	// ถ้าเปิด WithETagCache ส่ง ETag/Last-Modified ของ response ครั้งก่อนไปด้วย
+	// ไม่ใช้กับ FetchReader เพราะ 304 ไม่มี body ให้ส่งผ่าน BodyReader
+	var (
+		cached      etagEntry
+		conditional bool
+	)
+	if !cfg.streamBody {
+		cached, conditional = cfg.etags.prepare(req)
+	}
+	if err := runBeforeRequest(req, cfg); err != nil {
+		return finish(fmt.Errorf("before request hook: %w", err), false)
+	}
+	if err := signRequest(req, cfg); err != nil {
+		return finish(err, false)
+	}
+
+	// ส่ง request
+	sent = true
+	resp, err := cfg.clientFor(req.URL.Host).Do(req)
+	if err != nil {
+		// ถ้าหยุดเพราะ CheckRedirect จะได้ response สุดท้ายมาด้วย ใช้เก็บลำดับ URL ที่ผ่านมา
+		// และไม่ลองใหม่เพราะจะถูก redirect แบบเดิมอีก
+		if resp != nil {
+			result.Redirects = append(redirectChain(resp.Request), resp.Request.URL.String())
+		}
+		return finish(fmt.Errorf("error sending request: %w", err), resp == nil)
+	}
+	// ปิด resp.Body เสมอเพื่อคืนทรัพยากรเมื่อสิ้นสุดการทำงาน
+	// เว้นแต่ส่งต่อให้ผู้เรียกผ่าน BodyReader ซึ่งผู้เรียกต้องปิดเอง
+	defer func() {
+		if result.BodyReader == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	// คัดลอก header เก็บไว้ เพื่อให้ใช้งานได้แม้ในกรณีที่ status ไม่ใช่ 2xx
+	result.StatusCode = resp.StatusCode
+	result.Headers = resp.Header.Clone()
+	result.FinalURL = resp.Request.URL.String()
+	result.Redirects = redirectChain(resp.Request)
+	if err := runAfterResponse(resp, cfg); err != nil {
+		return finish(fmt.Errorf("after response hook: %w", err), false)
+	}
+
+	// 304 ตอบกลับ request แบบมีเงื่อนไขที่เราส่งเอง หมายความว่า body ใน cache ยังใช้ได้
+	if conditional && resp.StatusCode == http.StatusNotModified {
+		result.Body = bytes.Clone(cached.body)
+		result.FromCache = true
+		if cfg.bodyHash != nil {
+			h := cfg.bodyHash()
+			h.Write(result.Body)
+			result.BodyHash = hashHex(h)
+		}
+		return finish(nil, false)
+	}
+
+	// อ่านข้อมูลจาก response body
+	// อ่านก่อนตรวจ status เพื่อให้ผู้เรียกดู error payload ของ response ที่ไม่ใช่ 2xx ได้
+	var bodyReader io.Reader = resp.Body
+	if !cfg.disableDecompression && hasBody(method, resp) {
+		// ถอดการบีบอัดตาม Content-Encoding เพื่อให้ Body เป็นข้อมูลที่ถอดแล้วเสมอ
+		bodyReader, err = decodeBody(bodyReader, result.Headers)
+		if err != nil {
+			return finish(fmt.Errorf("error decompressing response body: %w", err), false)
+		}
+	}
+	// body ที่ส่งให้ผู้เรียกผ่าน BodyReader ไม่ถูกจำกัดขนาดหรือคำนวณ hash
+	decoded := bodyReader
+	if cfg.maxBodyBytes > 0 {
+		// อ่านเกิน limit ไป 1 byte เพื่อให้รู้ว่า body ใหญ่กว่าที่กำหนดหรือไม่
+		// limit นับจากข้อมูลที่ถอดการบีบอัดแล้ว จึงป้องกัน response ที่ขยายตัวมหาศาลได้ด้วย
+		bodyReader = io.LimitReader(bodyReader, cfg.maxBodyBytes+1)
+	}
+	// คำนวณ hash ไปพร้อมกับการอ่าน body เพื่อไม่ต้องอ่านซ้ำ
+	var hasher hash.Hash
+	if cfg.bodyHash != nil {
+		hasher = cfg.bodyHash()
+		bodyReader = io.TeeReader(bodyReader, hasher)
+	}
+
+	// ตรวจสอบ Status Code: 2xx ทุกตัว (เช่น 201 ของ POST หรือ 204 ของ PUT) ถือว่าสำเร็จ
+	// (ถ้าปิดการตาม redirect ไว้ 3xx คือผลลัพธ์ที่ตั้งใจรับ
+	// และถ้ากำหนด WithSkipStatusCheck ทุก status คือผลลัพธ์ที่ตั้งใจรับ)
+	redirect := cfg.noRedirects && resp.StatusCode >= 300 && resp.StatusCode < 400
+	statusOK := resp.StatusCode >= 200 && resp.StatusCode < 300 || redirect || cfg.skipStatusCheck
+
+	// ตรวจ Content-Type ก่อนอ่าน body เพื่อไม่ต้องอ่าน response ที่ไม่ใช่ชนิดที่ต้องการ
+	if r.ExpectContentType != "" && statusOK {
+		if err := checkContentType(r.ExpectContentType, resp.Header.Get("Content-Type")); err != nil {
+			return finish(err, false)
+		}
+	}
+
+	// response ของ HEAD ไม่มี body จึงไม่ต้องอ่าน และ Body เป็น nil
+	if method == http.MethodHead {
+		if !statusOK {
+			return finish(fmt.Errorf("unexpected status code: %d", resp.StatusCode), retryOn(r, cfg)(resp.StatusCode))
+		}
+		return finish(nil, false)
+	}
+
+	// FetchReader ส่ง body ของ response ที่สำเร็จให้ผู้เรียกอ่านเองโดยไม่อ่านเข้าหน่วยความจำ
+	if cfg.streamBody && statusOK {
+		result.BodyReader = &bodyReadCloser{Reader: decoded, closer: resp.Body, release: cancel}
+		return finish(nil, false)
+	}
+
+	// ถ้าใช้ WithBodyWriter เขียน body ของ response ที่สำเร็จลง writer แทนการเก็บไว้ในหน่วยความจำ
+	if cfg.bodyWriter != nil && statusOK {
+		err := writeBody(r.URL, bodyReader, &result, cfg)
+		if err == nil && hasher != nil {
+			result.BodyHash = hashHex(hasher)
+		}
+		return finish(err, false)
+	}
+
+	body, err := io.ReadAll(bodyReader)
+	if err != nil {
+		return finish(fmt.Errorf("error reading response body: %w", err), true)
+	}
+	if cfg.maxBodyBytes > 0 && int64(len(body)) > cfg.maxBodyBytes {
+		result.Body = body[:cfg.maxBodyBytes]
+		return finish(fmt.Errorf("response body exceeds %d bytes", cfg.maxBodyBytes), false)
+	}
+	result.Body = body
+	if hasher != nil {
+		result.BodyHash = hashHex(hasher)
+	}
+
+	if !statusOK {
+		return finish(fmt.Errorf("unexpected status code: %d", resp.StatusCode), retryOn(r, cfg)(resp.StatusCode))
+	}
+	// status 2xx ที่ body เล็กผิดปกติถือว่าล้มเหลวชั่วคราวและลองใหม่ได้
+	if r.MinBodySize > 0 && resp.StatusCode >= 200 && resp.StatusCode < 300 && int64(len(body)) < r.MinBodySize {
+		return finish(fmt.Errorf("response body too small: got %d bytes, expected at least %d", len(body), r.MinBodySize), true)
+	}
+	// ตรวจ body ด้วย validator ของ WithResponseValidator ก่อนเก็บเข้า cache
+	if cfg.validator != nil {
+		if err := cfg.validator(result); err != nil {
+			return finish(fmt.Errorf("response validation failed: %w", err), retryOn(r, cfg)(resp.StatusCode))
+		}
+	}
+	cfg.etags.store(req, resp, body)
+
+	return finish(nil, false)
+}
+
+// mergeQuery รวม query เข้ากับ query string เดิมของ u โดย key ที่ซ้ำกันจะใช้ค่าจาก query แทน
+func mergeQuery(u *url.URL, query url.Values) {
+	if len(query) == 0 {
+		return
+	}
+	merged := u.Query()
+	for key, values := range query {
+		merged[key] = values
+	}
+	u.RawQuery = merged.Encode()
+}
+
+// Fetch ดึงข้อมูลจาก url เดียว ปรับแต่งการทำงานได้ด้วย opts เช่น WithTimeout หรือ WithRetries
+func Fetch(url string, opts ...Option) APIResult {
+	return NewFetcher(opts...).Fetch(url)
+}
+
+// FetchAll ดึงข้อมูลจากทุก URL พร้อมกัน (หนึ่ง goroutine ต่อหนึ่ง URL เว้นแต่ใช้ WithConcurrency)
+// แล้วคืนผลลัพธ์ทั้งหมดเป็น slice หลังจาก goroutine ทุกตัวทำงานเสร็จ
+// ผลลัพธ์เรียงตามลำดับของ urls คือ results[i] เป็นผลของ urls[i]
+//
+// error ที่คืนมาไม่เป็น nil ก็ต่อเมื่อทุก request ล้มเหลว (รวม Error ทุกตัวด้วย errors.Join)
+// ถ้าล้มเหลวเพียงบางส่วน error เป็น nil และดูสาเหตุได้จาก Error ของแต่ละผลลัพธ์
+func FetchAll(urls []string, opts ...Option) ([]APIResult, error) {
+	return NewFetcher(opts...).FetchAll(urls)
+}
+
+// FetchAllContext ทำงานเหมือน FetchAll แต่ใช้ ctx กับทั้งชุด
+// ถ้า ctx มี deadline จะใช้เป็นเวลาสูงสุดของการเรียกทั้งหมด ไม่ว่าจะมีกี่ URL:
+// เมื่อหมดเวลา request ที่ยังทำงานอยู่จะถูกยกเลิก, request ที่ยังไม่เริ่มจะไม่ถูกส่ง
+// และฟังก์ชันจะคืนค่าทันที ผลลัพธ์ที่เสร็จแล้วยังอยู่ครบ ส่วนที่เหลือมี Error
+// ที่ errors.Is(err, context.DeadlineExceeded) เป็นจริง, error ที่คืนมาเป็นไปตามกฎเดียวกับ FetchAll
+func FetchAllContext(ctx context.Context, urls []string, opts ...Option) ([]APIResult, error) {
+	return NewFetcher(opts...).FetchAllContext(ctx, urls)
+}
+
+// FetchRequests ส่ง request ทุกตัวพร้อมกันด้วย method และ body ที่กำหนดไว้ในแต่ละ Request
+// การจัดการ response (latency, status, body) เหมือนกับ FetchAll ทุกประการ
+func FetchRequests(reqs []Request, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchRequests(reqs)
+}
+
+// FetchRequestsContext ทำงานเหมือน FetchRequests แต่ใช้ ctx
+func FetchRequestsContext(ctx context.Context, reqs []Request, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchRequestsContext(ctx, reqs)
+}
+
+// dispatch ส่ง request ทุกตัวพร้อมกันโดยจำกัดจำนวนตาม cfg.maxWorkers
+// แล้วเรียก deliver จาก goroutine ที่ทำ request นั้นเสร็จ (อาจถูกเรียกพร้อมกันหลายตัว)
+// จะคืนค่าเมื่อ goroutine ทุกตัวทำงานเสร็จแล้ว
+func dispatch(ctx context.Context, reqs []Request, cfg config, deliver func(int, APIResult)) {
+	// rate limiter และ retry budget หนึ่งตัวใช้ร่วมกันทั้งชุด
+	cfg.limiter = newRateLimiter(cfg.rateLimit)
+	// ผู้เรียกที่ dispatch หลายครั้งในการเรียกเดียว (เช่น FetchInBatches) สร้าง budget ไว้ให้ใช้ร่วมกันแล้ว
+	if cfg.retryBudget == nil {
+		cfg.retryBudget = newRetryBudget(cfg.retryBudgetSize)
+	}
+	if cfg.progress != nil {
+		deliver = withProgress(deliver, len(reqs), cfg.progress)
+	}
+
+	// สร้าง WaitGroup เพื่อรอให้ goroutine ทั้งหมดทำงานเสร็จ
+	var wg sync.WaitGroup
+
+	// เพิ่ม counter ใน WaitGroup เท่ากับจำนวน request (fetchAPI เรียก Done() หนึ่งครั้งต่อ request)
+	wg.Add(len(reqs))
+	if cfg.maxWorkers > 0 && cfg.maxWorkers < len(reqs) {
+		// สร้าง worker จำนวนคงที่ที่ดึงตำแหน่งของ request จาก jobs channel ทีละตัว
+		// จำนวน request ที่ทำงานพร้อมกันจึงไม่เกิน maxWorkers
+		jobs := make(chan int)
+		for i := 0; i < cfg.maxWorkers; i++ {
+			go func() {
+				for index := range jobs {
+					fetchAPI(ctx, index, reqs[index], cfg, &wg, deliver)
+				}
+			}()
+		}
+		for index := range reqs {
+			jobs <- index
+		}
+		close(jobs)
+	} else {
+		for index, r := range reqs {
+			go fetchAPI(ctx, index, r, cfg, &wg, deliver)
+		}
+	}
+
+	// รอจนกว่า counter ของ WaitGroup จะเป็น 0 (goroutine ทุกตัวเรียก Done())
+	wg.Wait()
+}
+
+// requestsFromURLs แปลง URL แต่ละตัวเป็น GET Request
+func requestsFromURLs(urls []string) []Request {
+	reqs := make([]Request, len(urls))
+	for i, url := range urls {
+		reqs[i] = Request{URL: url}
+	}
+	return reqs
+}
+
+// FetchAllLimited ทำงานเหมือน FetchAll แต่มี request ทำงานพร้อมกันไม่เกิน maxWorkers
+// ถ้า maxWorkers <= 0 จะไม่จำกัดจำนวน
+func FetchAllLimited(urls []string, maxWorkers int) ([]APIResult, error) {
+	return FetchAll(urls, WithConcurrency(maxWorkers))
+}
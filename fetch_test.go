@@ -0,0 +1,130 @@
+package goroutine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// WithConcurrency, WithRateLimit และ WithRetries ทำงานร่วมกันได้: จำนวน request พร้อมกันไม่เกิน n
+// แม้ระหว่างลองใหม่ และทุกครั้งที่ส่งจริง (รวมการลองใหม่) เว้นระยะตาม rate limit
+func TestConcurrencyRateLimitAndRetries(t *testing.T) {
+	const (
+		workers  = 2
+		perSec   = 20
+		interval = time.Second / perSec
+	)
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+		failed   = make(map[string]bool)
+		inflight atomic.Int32
+		peak     atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		// URL ที่ path เป็น /retry ตอบ 503 ครั้งแรกเพื่อให้ถูกลองใหม่
+		retry := r.URL.Path == "/retry" && !failed[r.URL.RawQuery]
+		if retry {
+			failed[r.URL.RawQuery] = true
+		}
+		mu.Unlock()
+
+		// ถือ request ไว้นานกว่าระยะห่างของ rate limit เพื่อให้ request ซ้อนกันได้ถ้าไม่จำกัดจำนวน
+		time.Sleep(3 * interval)
+		if retry {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var urls []string
+	for i := range 6 {
+		path := "/ok"
+		if i%3 == 0 {
+			path = "/retry"
+		}
+		urls = append(urls, fmt.Sprintf("%s%s?i=%d", server.URL, path, i))
+	}
+
+	results, err := FetchAll(urls,
+		WithConcurrency(workers),
+		WithRateLimit(perSec),
+		WithRetries(2),
+		WithBackoff(time.Millisecond, 0),
+	)
+	if err != nil {
+		t.Fatalf("FetchAll error: %v", err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			t.Errorf("%s: unexpected error: %v", result.URL, result.Error)
+		}
+	}
+
+	if got := peak.Load(); got > workers {
+		t.Errorf("peak in-flight requests = %d, want <= %d", got, workers)
+	}
+	if want := len(urls) + 2; len(arrivals) != want {
+		t.Fatalf("server saw %d requests, want %d (including retries)", len(arrivals), want)
+	}
+	slices.SortFunc(arrivals, func(a, b time.Time) int { return a.Compare(b) })
+	for i := 1; i < len(arrivals); i++ {
+		// เผื่อความคลาดเคลื่อนของเวลาที่ request มาถึง server
+		if gap := arrivals[i].Sub(arrivals[i-1]); gap < interval*7/10 {
+			t.Errorf("gap between request %d and %d = %v, want about %v", i-1, i, gap, interval)
+		}
+	}
+}
+
+// panicTransport ส่ง request ตามปกติ ยกเว้น path ที่ตรงกับ panicPath ซึ่งจะ panic
+type panicTransport struct {
+	panicPath string
+}
+
+func (t panicTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == t.panicPath {
+		panic("boom")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// panic ระหว่างดึง URL หนึ่งกลายเป็น Error ของ URL นั้น และ URL อื่นยังได้ผลลัพธ์ครบ
+func TestFetchAllRecoversPanic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/panic", server.URL + "/b"}
+	client := &http.Client{Transport: panicTransport{panicPath: "/panic"}}
+	results, err := FetchAll(urls, WithClient(client))
+	if err != nil {
+		t.Fatalf("FetchAll error: %v", err)
+	}
+
+	if results[1].Error == nil || !strings.Contains(results[1].Error.Error(), "panic while fetching: boom") {
+		t.Errorf("panicking URL error = %v, want panic error", results[1].Error)
+	}
+	for _, i := range []int{0, 2} {
+		if results[i].Error != nil || string(results[i].Body) != "ok" {
+			t.Errorf("%s: got error %v, body %q; want body %q", results[i].URL, results[i].Error, results[i].Body, "ok")
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package goroutine
+
+import "context"
+
+// Fetcher เก็บการตั้งค่าที่ใช้ร่วมกัน (client, header, นโยบายการลองใหม่, จำนวน request พร้อมกัน ฯลฯ)
+// สร้างครั้งเดียวแล้วใช้ซ้ำได้ เหมือนกับการใช้ http.Client
+// ทุก method ใช้ http.Client ตัวเดียวกัน จึง reuse connection ข้ามการเรียกได้
+// Fetcher ปลอดภัยสำหรับการใช้งานจากหลาย goroutine พร้อมกัน
+type Fetcher struct {
+	cfg config
+}
+
+// NewFetcher สร้าง Fetcher จาก opts ถ้าไม่ได้กำหนด WithClient จะใช้ http.Client ที่ใช้ร่วมกันทั้งแพ็กเกจ
+// เว้นแต่ option อย่าง WithNoRedirects หรือ WithProxy ต้องใช้ client แยก ซึ่งจะสร้างให้หนึ่งตัวต่อ Fetcher
+func NewFetcher(opts ...Option) *Fetcher {
+	cfg := newConfig(opts)
+	cfg.client = buildClient(cfg)
+	cfg.hostClients = newHostClients(cfg.clientPerHost, cfg.client)
+	cfg.breaker = newCircuitBreaker(cfg.breakerThreshold, cfg.breakerCooldown)
+	cfg.etags = newETagCache(cfg.etagCache)
+	cfg.cache = newResponseCache(cfg.cacheTTL)
+	cfg.lifecycle = newLifecycle()
+	warnTLS(cfg)
+	warnTransport(cfg)
+	return &Fetcher{cfg: cfg}
+}
+
+// Fetch ดึงข้อมูลจาก url เดียว
+func (f *Fetcher) Fetch(url string) APIResult {
+	return f.FetchContext(context.Background(), url)
+}
+
+// FetchContext ทำงานเหมือน Fetch แต่หยุดเมื่อ ctx ถูกยกเลิก
+func (f *Fetcher) FetchContext(ctx context.Context, url string) APIResult {
+	return fetch(ctx, Request{URL: url}, f.cfg)
+}
+
+// FetchAll ดึงข้อมูลจากทุก URL พร้อมกัน ผลลัพธ์เรียงตามลำดับของ urls
+// error ไม่เป็น nil ก็ต่อเมื่อทุก request ล้มเหลว เหมือนฟังก์ชัน FetchAll
+func (f *Fetcher) FetchAll(urls []string) ([]APIResult, error) {
+	return f.FetchAllContext(context.Background(), urls)
+}
+
+// FetchAllContext ทำงานเหมือน FetchAll แต่หยุดเมื่อ ctx ถูกยกเลิก
+// ดูรายละเอียดการใช้ deadline ของทั้งชุดได้ที่ฟังก์ชัน FetchAllContext
+func (f *Fetcher) FetchAllContext(ctx context.Context, urls []string) ([]APIResult, error) {
+	if f.cfg.failFast {
+		return f.fetchFailFast(ctx, requestsFromURLs(urls))
+	}
+	results := f.FetchRequestsContext(ctx, requestsFromURLs(urls))
+	return results, allFailed(results)
+}
+
+// FetchRequests ส่ง request ทุกตัวพร้อมกัน ผลลัพธ์เรียงตามลำดับของ reqs
+func (f *Fetcher) FetchRequests(reqs []Request) []APIResult {
+	return f.FetchRequestsContext(context.Background(), reqs)
+}
+
+// FetchRequestsContext ทำงานเหมือน FetchRequests แต่หยุดเมื่อ ctx ถูกยกเลิก
+func (f *Fetcher) FetchRequestsContext(ctx context.Context, reqs []Request) []APIResult {
+	// จองพื้นที่ผลลัพธ์ไว้ล่วงหน้า แต่ละ goroutine เขียนลงตำแหน่งของ request ตัวเอง
+	// ลำดับของผลลัพธ์จึงตรงกับลำดับของ reqs ไม่ใช่ลำดับที่ทำงานเสร็จ
+	// และ goroutine แต่ละตัวไม่เขียนทับกัน
+	results := make([]APIResult, len(reqs))
+	dispatch(ctx, reqs, f.cfg, func(index int, result APIResult) {
+		results[index] = result
+	})
+	return results
+}
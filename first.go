@@ -0,0 +1,52 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FetchFirst ส่ง request ไปยังทุก URL พร้อมกัน (เช่น mirror หลายตัว)
+// แล้วคืนผลลัพธ์แรกที่สำเร็จ request ที่เหลือจะถูกยกเลิกผ่าน context ที่แยกออกมาจาก ctx
+//
+// ถ้าทุก URL ล้มเหลว จะคืนผลลัพธ์ที่ใช้เวลาน้อยที่สุด โดย Error รวม error ของทุก URL ไว้
+func FetchFirst(ctx context.Context, urls []string, opts ...Option) APIResult {
+	return NewFetcher(opts...).FetchFirst(ctx, urls)
+}
+
+// FetchFirst ทำงานเหมือนฟังก์ชัน FetchFirst แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchFirst(ctx context.Context, urls []string) APIResult {
+	if len(urls) == 0 {
+		return APIResult{Error: errors.New("no URLs to fetch")}
+	}
+
+	// ยกเลิก request ที่ยังทำงานอยู่ทั้งหมดเมื่อได้ผลลัพธ์แล้ว
+	ctx, cancel := withCancelNotNeeded(ctx)
+	defer cancel()
+
+	// buffer เท่ากับจำนวน URL เพื่อไม่ให้ goroutine ที่ทำเสร็จทีหลังค้างอยู่ตอนส่งผลลัพธ์
+	resultsChan := make(chan APIResult, len(urls))
+	for _, url := range urls {
+		go func() {
+			resultsChan <- fetch(ctx, Request{URL: url}, f.cfg)
+		}()
+	}
+
+	var (
+		fastest APIResult
+		failed  []APIResult
+	)
+	for i := range urls {
+		result := <-resultsChan
+		if result.Error == nil {
+			return result
+		}
+		if i == 0 || result.Latency < fastest.Latency {
+			fastest = result
+		}
+		failed = append(failed, result)
+	}
+
+	fastest.Error = fmt.Errorf("all %d requests failed: %w", len(urls), Errors(failed))
+	return fastest
+}
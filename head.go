@@ -0,0 +1,19 @@
+package goroutine
+
+import "net/http"
+
+// FetchHead ส่ง HEAD request ไปยังทุก URL พร้อมกัน เพื่อตรวจว่าใช้งานได้โดยไม่ต้องดาวน์โหลด body
+// ผลลัพธ์มี StatusCode, Headers และ Latency แต่ Body เป็น nil เสมอ
+// ผลลัพธ์เรียงตามลำดับของ urls เหมือน FetchAll
+func FetchHead(urls []string, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchHead(urls)
+}
+
+// FetchHead ทำงานเหมือนฟังก์ชัน FetchHead แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchHead(urls []string) []APIResult {
+	reqs := requestsFromURLs(urls)
+	for i := range reqs {
+		reqs[i].Method = http.MethodHead
+	}
+	return f.FetchRequests(reqs)
+}
@@ -0,0 +1,110 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// WithBeforeRequest เรียก hook กับ request ทุกครั้งก่อนส่ง (รวมการลองใหม่) หลังใส่ header และ auth แล้ว
+// ใช้แก้ไข request ได้ เช่น ลงชื่อแบบ AWS SigV4 หรือใส่ header ที่คำนวณต่อ request
+// ถ้า hook คืน error จะไม่ส่ง request และ error นั้นจะเป็น APIResult.Error (ไม่ลองใหม่)
+// กำหนดได้หลายตัว โดยถูกเรียกตามลำดับที่กำหนดและหยุดที่ตัวแรกที่คืน error
+// hook อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+func WithBeforeRequest(hook func(*http.Request) error) Option {
+	return func(c *config) {
+		c.beforeRequest = append(c.beforeRequest, hook)
+	}
+}
+
+// runBeforeRequest เรียก hook ของ WithBeforeRequest ทุกตัวตามลำดับ
+func runBeforeRequest(req *http.Request, cfg config) error {
+	for _, hook := range cfg.beforeRequest {
+		if err := hook(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithAfterResponse เรียก hook กับ response ทุกครั้งหลังได้รับ response แต่ก่อนอ่าน body
+// ใช้ตรวจหรือปรับ response ได้ เช่น เก็บ metrics จาก header, ปฏิเสธตาม header หรือห่อ resp.Body
+// ถ้า hook คืน error จะไม่อ่าน body และ error นั้นจะเป็น APIResult.Error (ไม่ลองใหม่)
+// โดย StatusCode และ Headers ของผลลัพธ์ยังถูกเก็บไว้, hook ไม่ต้องปิด resp.Body เอง
+// กำหนดได้หลายตัว โดยถูกเรียกตามลำดับที่กำหนดและหยุดที่ตัวแรกที่คืน error
+func WithAfterResponse(hook func(*http.Response) error) Option {
+	return func(c *config) {
+		c.afterResponse = append(c.afterResponse, hook)
+	}
+}
+
+// runAfterResponse เรียก hook ของ WithAfterResponse ทุกตัวตามลำดับ
+func runAfterResponse(resp *http.Response, cfg config) error {
+	for _, hook := range cfg.afterResponse {
+		if err := hook(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithResponseValidator ตรวจ response ที่ผ่านการตรวจ status แล้วจาก body เช่น JSON ที่ตอบ {"ok": false} มากับ 200
+// validator ถูกเรียกหนึ่งครั้งต่อความพยายามหลังอ่าน body แล้ว โดย res มี StatusCode, Headers และ Body
+// ถ้าคืน error จะเป็น APIResult.Error (Body ยังอยู่) และลองใหม่ถ้าเงื่อนไขของ WithRetryOn
+// หรือ Request.RetryOn อนุญาต status code นั้น (DefaultRetryOn ไม่ลองใหม่สำหรับ 2xx)
+// ไม่ถูกเรียกกับ body ที่เขียนลง WithBodyWriter หรือส่งผ่าน FetchReader
+// validator อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+func WithResponseValidator(validator func(res APIResult) error) Option {
+	return func(c *config) {
+		c.validator = validator
+	}
+}
+
+// WithErrorCallback เรียก callback ทันทีที่ URL ใดล้มเหลว (network error หรือ status ที่ไม่ใช่ 2xx)
+// ด้วยผลลัพธ์สุดท้ายหลังลองใหม่ครบแล้ว เช่น เพื่อส่งไปยังระบบแจ้งเตือนระหว่างที่ชุดยังทำงานอยู่
+// ใช้เงื่อนไขเดียวกับ Failed จึงรวม response 3xx จาก WithNoRedirects ด้วย
+// แต่ไม่รวม request ที่ถูกยกเลิกเพราะไม่ต้องการผลแล้ว เช่น mirror ที่แพ้ใน FetchFirst
+// callback ถูกเรียกจาก goroutine ของแต่ละ request โดยตรง อาจถูกเรียกพร้อมกัน
+// จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกันและไม่ควรบล็อกนาน
+func WithErrorCallback(callback func(APIResult)) Option {
+	return func(c *config) {
+		c.onError = callback
+	}
+}
+
+// reportError ส่ง result ให้ callback ของ WithErrorCallback ถ้า result ล้มเหลว
+// ยกเว้น request ที่ถูกยกเลิกด้วย cancel ของ withCancelNotNeeded
+func (c config) reportError(ctx context.Context, result APIResult) {
+	if c.onError == nil || succeeded(result) {
+		return
+	}
+	if errors.Is(result.Error, context.Canceled) && notNeeded(ctx) {
+		return
+	}
+	c.onError(result)
+}
+
+// notNeededKey คือ key ของ context ที่เก็บว่า request ถูกยกเลิกเพราะไม่ต้องการผลแล้วหรือไม่
+type notNeededKey struct{}
+
+// withCancelNotNeeded ทำงานเหมือน context.WithCancel แต่ request ที่ถูกยกเลิกด้วย cancel ที่คืนมา
+// ถือว่าไม่ต้องการผลแล้ว (เช่น mirror ที่แพ้ใน FetchFirst) จึงไม่ใช่ความล้มเหลวที่ต้องรายงาน
+// Error ของ request เหล่านั้นยังเป็น context.Canceled ตามปกติ
+func withCancelNotNeeded(parent context.Context) (context.Context, context.CancelFunc) {
+	flag := new(atomic.Bool)
+	ctx, cancel := context.WithCancel(context.WithValue(parent, notNeededKey{}, flag))
+	return ctx, func() {
+		// ถ้าถูกยกเลิกจาก parent ไปแล้ว การยกเลิกนั้นมาจากผู้เรียกและยังต้องรายงาน
+		if ctx.Err() == nil {
+			flag.Store(true)
+		}
+		cancel()
+	}
+}
+
+// notNeeded รายงานว่า ctx ถูกยกเลิกด้วย cancel ของ withCancelNotNeeded หรือไม่
+func notNeeded(ctx context.Context) bool {
+	flag, _ := ctx.Value(notNeededKey{}).(*atomic.Bool)
+	return flag != nil && flag.Load()
+}
@@ -0,0 +1,117 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// errorRecorder เก็บผลลัพธ์ที่ WithErrorCallback ได้รับ
+type errorRecorder struct {
+	mu      sync.Mutex
+	results []APIResult
+}
+
+func (r *errorRecorder) record(result APIResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+func (r *errorRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.results)
+}
+
+// mirror ที่แพ้ใน FetchFirst ถูกยกเลิกโดย library จึงไม่ถูกรายงานเป็นความล้มเหลว
+func TestErrorCallbackSkipsFetchFirstLosers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var errs errorRecorder
+	slowDone := make(chan struct{})
+	f := NewFetcher(
+		WithErrorCallback(errs.record),
+		WithLogger(func(event string, result APIResult) {
+			if event == EventDone && result.URL == server.URL+"/slow" {
+				close(slowDone)
+			}
+		}),
+	)
+	result := f.FetchFirst(context.Background(), []string{server.URL + "/fast", server.URL + "/slow"})
+	if result.Error != nil {
+		t.Fatalf("FetchFirst error: %v", result.Error)
+	}
+	// รอให้ request ที่แพ้จบก่อนนับจำนวนครั้งที่ callback ถูกเรียก
+	// (callback ถูกเรียกหลัง EventDone ใน goroutine เดียวกัน จึงเผื่อเวลาให้อีกเล็กน้อย)
+	select {
+	case <-slowDone:
+	case <-time.After(time.Second):
+		t.Fatal("losing request did not finish")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := errs.count(); n != 0 {
+		t.Errorf("error callback called %d times, want 0 (first: %v)", n, errs.results[0].Error)
+	}
+}
+
+// การยกเลิกจาก ctx ของผู้เรียกยังถูกรายงานตามปกติ
+func TestErrorCallbackReportsCallerCancel(t *testing.T) {
+	var errs errorRecorder
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := NewFetcher(WithErrorCallback(errs.record)).FetchContext(ctx, "http://127.0.0.1:1")
+	if !errors.Is(result.Error, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", result.Error)
+	}
+	if n := errs.count(); n != 1 {
+		t.Errorf("error callback called %d times, want 1", n)
+	}
+}
+
+// countingMetrics นับจำนวน request ที่สำเร็จและล้มเหลว
+type countingMetrics struct {
+	success, failure atomic.Int32
+}
+
+func (m *countingMetrics) ObserveLatency(string, time.Duration) {}
+func (m *countingMetrics) IncSuccess(string)                    { m.success.Add(1) }
+func (m *countingMetrics) IncFailure(string)                    { m.failure.Add(1) }
+
+// WithErrorCallback และ Metrics ใช้เงื่อนไขความสำเร็จเดียวกัน: 3xx จาก WithNoRedirects ไม่มี Error แต่ล้มเหลว
+func TestErrorCallbackMatchesMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	var (
+		errs    errorRecorder
+		metrics countingMetrics
+	)
+	result := Fetch(server.URL, WithNoRedirects(), WithErrorCallback(errs.record), WithMetrics(&metrics))
+	if result.Error != nil || result.StatusCode != http.StatusFound {
+		t.Fatalf("got error %v, status %d; want 302 without error", result.Error, result.StatusCode)
+	}
+	if n := errs.count(); n != 1 {
+		t.Errorf("error callback called %d times, want 1", n)
+	}
+	if s, f := metrics.success.Load(), metrics.failure.Load(); s != 0 || f != 1 {
+		t.Errorf("metrics success %d, failure %d; want 0 and 1", s, f)
+	}
+}
@@ -0,0 +1,55 @@
+package goroutine
+
+import (
+	"net/http"
+	"sync"
+)
+
+// WithClientPerHost ใช้ http.Client แยกกันสำหรับแต่ละ host (ตาม host ของ URL) แทน client ตัวเดียว
+// แต่ละ client มี transport ของตัวเอง host หนึ่งจึงมี connection pool และ idle connection แยกจาก host อื่น
+// ทำให้ host ที่มี request มากไม่แย่ง connection ของ host อื่น, client ถูกสร้างเมื่อใช้ครั้งแรกแล้วใช้ซ้ำ
+// ตลอดอายุของ Fetcher โดยคัดลอกการตั้งค่าจาก client หลัก (WithClient, WithTransport, WithProxy ฯลฯ)
+// ถ้า transport ของ client หลักไม่ใช่ *http.Transport ทุก host จะใช้ transport นั้นร่วมกัน
+func WithClientPerHost() Option {
+	return func(c *config) {
+		c.clientPerHost = true
+	}
+}
+
+// hostClients เก็บ http.Client ของแต่ละ host ใช้ร่วมกันได้จากหลาย goroutine
+type hostClients struct {
+	mu      sync.Mutex
+	base    *http.Client
+	clients map[string]*http.Client
+}
+
+// newHostClients สร้าง hostClients ที่คัดลอก client จาก base คืน nil ถ้าไม่ได้เปิดใช้
+func newHostClients(enabled bool, base *http.Client) *hostClients {
+	if !enabled {
+		return nil
+	}
+	return &hostClients{base: base, clients: make(map[string]*http.Client)}
+}
+
+// clientFor คืน client ของ host โดยสร้างให้ถ้ายังไม่มี
+func (c *hostClients) clientFor(host string) *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	client, ok := c.clients[host]
+	if !ok {
+		copied := *c.base
+		copied.Transport = buildTransport(c.base.Transport, nil)
+		client = &copied
+		c.clients[host] = client
+	}
+	return client
+}
+
+// clientFor คืน client ที่ใช้ส่ง request ไปยัง host ตามการตั้งค่าของ WithClientPerHost
+func (c config) clientFor(host string) *http.Client {
+	if c.hostClients == nil {
+		return c.client
+	}
+	return c.hostClients.clientFor(host)
+}
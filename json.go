@@ -0,0 +1,52 @@
+package goroutine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// FetchJSON ดึงข้อมูลจาก url แล้ว unmarshal body ที่เป็น JSON ลงใน T
+// ถ้าเกิดข้อผิดพลาดใดๆ (network, status ไม่ใช่ 2xx หรือ JSON ไม่ถูกต้อง)
+// จะคืนค่า zero value ของ T พร้อม error ที่ห่อสาเหตุไว้
+func FetchJSON[T any](url string, opts ...Option) (T, error) {
+	return decodeJSON[T](Fetch(url, opts...))
+}
+
+// FetchJSONPost แปลง payload เป็น JSON แล้ว POST ไปที่ url พร้อม Content-Type: application/json
+// จากนั้น unmarshal response ลงใน T เหมือน FetchJSON
+// ถ้าแปลง payload ไม่สำเร็จจะคืน error ทันทีโดยไม่ส่ง request
+func FetchJSONPost[T any](url string, payload any, opts ...Option) (T, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("encode JSON for %s: %w", url, err)
+	}
+
+	r := Request{
+		Method:  http.MethodPost,
+		URL:     url,
+		Body:    body,
+		Headers: http.Header{"Content-Type": {"application/json"}},
+	}
+	return decodeJSON[T](fetch(context.Background(), r, NewFetcher(opts...).cfg))
+}
+
+// decodeJSON unmarshal body ของ result ลงใน T
+// คืน zero value ของ T พร้อม error ถ้า result มี Error หรือ JSON ไม่ถูกต้อง
+// ส่วน 204 ไม่มี body ให้ decode จึงคืน zero value โดยไม่มี error
+func decodeJSON[T any](result APIResult) (T, error) {
+	var value T
+	if result.Error != nil {
+		return value, fmt.Errorf("fetch %s: %w", result.URL, result.Error)
+	}
+	if result.StatusCode == http.StatusNoContent {
+		return value, nil
+	}
+	if err := json.Unmarshal(result.Body, &value); err != nil {
+		var zero T
+		return zero, fmt.Errorf("decode JSON from %s: %w", result.URL, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,49 @@
+package goroutine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// FetchJSONPost ถือว่า 201 Created เป็นผลสำเร็จและ decode body ที่ตอบกลับ
+func TestFetchJSONPostCreated(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var in item
+		json.NewDecoder(r.Body).Decode(&in)
+		in.ID = 7
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(in)
+	}))
+	defer server.Close()
+
+	got, err := FetchJSONPost[item](server.URL, item{Name: "book"})
+	if err != nil {
+		t.Fatalf("FetchJSONPost error: %v", err)
+	}
+	if want := (item{ID: 7, Name: "book"}); got != want {
+		t.Errorf("FetchJSONPost = %+v, want %+v", got, want)
+	}
+}
+
+// 204 No Content เป็นผลสำเร็จ และ FetchJSONPost คืน zero value โดยไม่มี error
+func TestFetchNoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	result := FetchRequests([]Request{{Method: http.MethodPut, URL: server.URL, Body: []byte("x")}})[0]
+	if result.Error != nil || result.StatusCode != http.StatusNoContent {
+		t.Errorf("PUT: error %v, status %d; want 204 without error", result.Error, result.StatusCode)
+	}
+	if got, err := FetchJSONPost[map[string]string](server.URL, map[string]string{"a": "b"}); err != nil || got != nil {
+		t.Errorf("FetchJSONPost = %v, %v; want nil map without error", got, err)
+	}
+}
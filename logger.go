@@ -0,0 +1,33 @@
+package goroutine
+
+// เหตุการณ์ที่ส่งให้ logger ที่กำหนดผ่าน WithLogger
+const (
+	EventStart = "start" // ก่อนเริ่มส่ง request ของ URL นี้ (result มีเพียง URL)
+	EventRetry = "retry" // ความพยายามล้มเหลวและกำลังจะลองใหม่ (result คือผลของความพยายามนั้น)
+	EventDone  = "done"  // ทำงานเสร็จแล้ว (result คือผลลัพธ์สุดท้าย พร้อม Latency และ StatusCode)
+
+	// EventRetryBudgetExhausted ความพยายามล้มเหลวและควรลองใหม่ แต่ budget ของ WithRetryBudget หมดแล้ว
+	// result คือผลของความพยายามนั้นซึ่งจะเป็นผลลัพธ์สุดท้าย
+	EventRetryBudgetExhausted = "retry_budget_exhausted"
+
+	// EventWarning เตือนการตั้งค่าที่ไม่ปลอดภัยหรือไม่มีผล เช่น WithInsecureSkipVerify
+	// ส่งครั้งเดียวตอนสร้าง Fetcher โดย result มีเพียง Error ที่เป็นข้อความเตือน
+	EventWarning = "warning"
+)
+
+// WithLogger กำหนดฟังก์ชันที่ถูกเรียกในแต่ละช่วงของ request: EventStart, EventRetry และ EventDone
+// รวมถึง EventWarning เมื่อสร้าง Fetcher ด้วยการตั้งค่าที่ไม่ปลอดภัยหรือไม่มีผล
+// logger อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+// ถ้าไม่กำหนดจะไม่มีการเรียกใดๆ เลย
+func WithLogger(logger func(event string, result APIResult)) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// log ส่งเหตุการณ์ให้ logger ถ้ามีการกำหนดไว้
+func (c config) log(event string, result APIResult) {
+	if c.logger != nil {
+		c.logger(event, result)
+	}
+}
@@ -0,0 +1,167 @@
+package goroutine
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// apiResultJSON คือรูปแบบ JSON ของ APIResult
+type apiResultJSON struct {
+	URL           string        `json:"url"`
+	FinalURL      string        `json:"final_url,omitempty"`
+	Redirects     []string      `json:"redirects,omitempty"`
+	StatusCode    int           `json:"status_code"`
+	Headers       http.Header   `json:"headers,omitempty"`
+	Body          *string       `json:"body"`
+	BytesWritten  int64         `json:"bytes_written,omitempty"`
+	BodyHash      string        `json:"body_hash,omitempty"`
+	BodyBase64    bool          `json:"body_base64,omitempty"` // true ถ้า Body ถูก encode เป็น base64
+	Error         *string       `json:"error"`
+	LatencyMs     float64       `json:"latency_ms"`
+	StartTime     time.Time     `json:"start_time,omitzero"`
+	EndTime       time.Time     `json:"end_time,omitzero"`
+	Attempts      []attemptJSON `json:"attempts"`
+	FromCache     bool          `json:"from_cache,omitempty"`
+	Timing        *timingJSON   `json:"timing,omitempty"`
+	CorrelationID string        `json:"correlation_id,omitempty"`
+}
+
+// attemptJSON คือรูปแบบ JSON ของ Attempt
+type attemptJSON struct {
+	StatusCode int     `json:"status_code"`
+	Error      *string `json:"error"`
+	LatencyMs  float64 `json:"latency_ms"`
+}
+
+// timingJSON คือรูปแบบ JSON ของ Timing โดยเวลาเป็นมิลลิวินาที
+type timingJSON struct {
+	DNSLookupMs       float64 `json:"dns_lookup_ms"`
+	ConnectMs         float64 `json:"connect_ms"`
+	TLSHandshakeMs    float64 `json:"tls_handshake_ms"`
+	TimeToFirstByteMs float64 `json:"time_to_first_byte_ms"`
+}
+
+// MarshalJSON แปลง APIResult เป็น JSON ที่อ่านง่าย:
+// Error เป็นข้อความ (หรือ null), Latency เป็นมิลลิวินาทีใน latency_ms
+// และ Body เป็นข้อความ UTF-8 ถ้าเป็นไปได้ ไม่เช่นนั้นจะเป็น base64 พร้อม body_base64 เป็น true
+func (r APIResult) MarshalJSON() ([]byte, error) {
+	out := apiResultJSON{
+		URL:           r.URL,
+		FinalURL:      r.FinalURL,
+		Redirects:     r.Redirects,
+		StatusCode:    r.StatusCode,
+		Headers:       r.Headers,
+		BytesWritten:  r.BytesWritten,
+		BodyHash:      r.BodyHash,
+		LatencyMs:     milliseconds(r.Latency),
+		StartTime:     r.StartTime,
+		EndTime:       r.EndTime,
+		FromCache:     r.FromCache,
+		CorrelationID: r.CorrelationID,
+	}
+	if r.Body != nil {
+		body := string(r.Body)
+		if !utf8.Valid(r.Body) {
+			body = base64.StdEncoding.EncodeToString(r.Body)
+			out.BodyBase64 = true
+		}
+		out.Body = &body
+	}
+	out.Error = errorMessage(r.Error)
+	if r.Timing != (Timing{}) {
+		out.Timing = &timingJSON{
+			DNSLookupMs:       milliseconds(r.Timing.DNSLookup),
+			ConnectMs:         milliseconds(r.Timing.Connect),
+			TLSHandshakeMs:    milliseconds(r.Timing.TLSHandshake),
+			TimeToFirstByteMs: milliseconds(r.Timing.TimeToFirstByte),
+		}
+	}
+	for _, attempt := range r.Attempts {
+		out.Attempts = append(out.Attempts, attemptJSON{
+			StatusCode: attempt.StatusCode,
+			Error:      errorMessage(attempt.Error),
+			LatencyMs:  milliseconds(attempt.Latency),
+		})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON อ่าน JSON ที่สร้างจาก MarshalJSON กลับเป็น APIResult
+// Error ที่ได้จะเป็น error ที่มีเพียงข้อความเดิม (ใช้ errors.Is กับ error ต้นทางไม่ได้)
+func (r *APIResult) UnmarshalJSON(data []byte) error {
+	var in apiResultJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	result := APIResult{
+		URL:           in.URL,
+		FinalURL:      in.FinalURL,
+		Redirects:     in.Redirects,
+		StatusCode:    in.StatusCode,
+		Headers:       in.Headers,
+		BytesWritten:  in.BytesWritten,
+		BodyHash:      in.BodyHash,
+		Latency:       fromMilliseconds(in.LatencyMs),
+		StartTime:     in.StartTime,
+		EndTime:       in.EndTime,
+		FromCache:     in.FromCache,
+		CorrelationID: in.CorrelationID,
+	}
+	if in.Body != nil {
+		if in.BodyBase64 {
+			body, err := base64.StdEncoding.DecodeString(*in.Body)
+			if err != nil {
+				return err
+			}
+			result.Body = body
+		} else {
+			result.Body = []byte(*in.Body)
+		}
+	}
+	result.Error = messageError(in.Error)
+	if in.Timing != nil {
+		result.Timing = Timing{
+			DNSLookup:       fromMilliseconds(in.Timing.DNSLookupMs),
+			Connect:         fromMilliseconds(in.Timing.ConnectMs),
+			TLSHandshake:    fromMilliseconds(in.Timing.TLSHandshakeMs),
+			TimeToFirstByte: fromMilliseconds(in.Timing.TimeToFirstByteMs),
+		}
+	}
+	for _, attempt := range in.Attempts {
+		result.Attempts = append(result.Attempts, Attempt{
+			StatusCode: attempt.StatusCode,
+			Error:      messageError(attempt.Error),
+			Latency:    fromMilliseconds(attempt.LatencyMs),
+		})
+	}
+	*r = result
+	return nil
+}
+
+// errorMessage คืนข้อความของ err หรือ nil ถ้า err เป็น nil (เป็น null ใน JSON)
+func errorMessage(err error) *string {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	return &msg
+}
+
+// messageError สร้าง error จากข้อความที่ได้จาก errorMessage
+func messageError(msg *string) error {
+	if msg == nil {
+		return nil
+	}
+	return errors.New(*msg)
+}
+
+// fromMilliseconds แปลงจำนวนมิลลิวินาทีกลับเป็น time.Duration
+func fromMilliseconds(ms float64) time.Duration {
+	return time.Duration(math.Round(ms * float64(time.Millisecond)))
+}
@@ -0,0 +1,40 @@
+package goroutine
+
+import "time"
+
+// Metrics รับค่าสถิติของแต่ละ request เพื่อส่งต่อไปยังระบบ metrics เช่น Prometheus
+// แพ็กเกจนี้ไม่ผูกกับ library ใดๆ ผู้ใช้ implement interface นี้กับ registry ของตัวเอง
+// ทุก method ถูกเรียกครั้งเดียวต่อหนึ่ง request เมื่อได้ผลลัพธ์สุดท้าย (หลังลองใหม่ครบแล้ว)
+// และอาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+type Metrics interface {
+	ObserveLatency(url string, d time.Duration) // เวลาที่ใช้ของความพยายามครั้งสุดท้าย (APIResult.Latency)
+	IncSuccess(url string)                      // request ที่สำเร็จตามเงื่อนไขของ Successful
+	IncFailure(url string)                      // request ที่ล้มเหลวตามเงื่อนไขของ Failed
+}
+
+// noopMetrics คือ Metrics เริ่มต้นที่ไม่ทำอะไรเลย
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveLatency(string, time.Duration) {}
+func (noopMetrics) IncSuccess(string)                    {}
+func (noopMetrics) IncFailure(string)                    {}
+
+// WithMetrics ส่งค่าสถิติของทุก request ให้ m, ถ้าไม่กำหนด (หรือ m เป็น nil) จะไม่เก็บค่าใดๆ
+func WithMetrics(m Metrics) Option {
+	return func(c *config) {
+		if m == nil {
+			m = noopMetrics{}
+		}
+		c.metrics = m
+	}
+}
+
+// observe ส่งผลลัพธ์สุดท้ายของ request ให้ metrics
+func (c config) observe(result APIResult) {
+	c.metrics.ObserveLatency(result.URL, result.Latency)
+	if succeeded(result) {
+		c.metrics.IncSuccess(result.URL)
+	} else {
+		c.metrics.IncFailure(result.URL)
+	}
+}
@@ -0,0 +1,308 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout คือ timeout ของแต่ละ request เมื่อไม่ได้กำหนด WithTimeout
+const DefaultTimeout = 10 * time.Second
+
+// config เก็บค่าที่ใช้ปรับแต่งการดึงข้อมูล ถูกกำหนดผ่าน Option เท่านั้น
+type config struct {
+	// timeout ของแต่ละ request แยกกัน ไม่ใช่ของทั้งชุด
+	// URL ที่ช้าตัวหนึ่งจึงไม่กินเวลาของ URL อื่น, ค่า 0 หมายถึงไม่มี timeout
+	timeout time.Duration
+
+	// maxResponseTime คือเวลาสูงสุดที่ response ที่สำเร็จใช้ได้ก่อนถูกถือว่าล้มเหลว (0 หมายถึงไม่จำกัด)
+	maxResponseTime time.Duration
+
+	// failFast ยกเลิกทั้งชุดของ FetchAll เมื่อ request แรกล้มเหลว
+	failFast bool
+
+	// maxWorkers จำกัดจำนวน request ที่ทำงานพร้อมกัน
+	// ค่า <= 0 หมายถึงไม่จำกัด (หนึ่ง goroutine ต่อหนึ่ง URL)
+	maxWorkers int
+
+	// maxAttempts คือจำนวนครั้งสูงสุดที่จะส่ง request ต่อหนึ่ง URL (รวมครั้งแรก)
+	// จะลองใหม่เมื่อเกิด network error หรือ status ที่ retryOn อนุญาต, ค่า <= 1 หมายถึงไม่ลองใหม่
+	maxAttempts int
+
+	// retryOn ตัดสินว่า status code ใดควรลองใหม่ ถ้าเป็น nil จะใช้ DefaultRetryOn
+	retryOn func(statusCode int) bool
+
+	// onRetry ถูกเรียกก่อนรอลองใหม่ทุกครั้ง (nil หมายถึงไม่เรียก)
+	onRetry func(url string, attempt int, err error, nextDelay time.Duration)
+
+	// backoffBase คือเวลารอก่อนลองใหม่ครั้งแรก แล้วเพิ่มเป็นสองเท่าในแต่ละครั้ง (100ms, 200ms, 400ms, ...)
+	// ค่า 0 หมายถึงลองใหม่ทันที
+	backoffBase time.Duration
+
+	// backoffMax คือเวลารอสูงสุดระหว่างการลองใหม่ ค่า 0 หมายถึงใช้ DefaultMaxBackoff
+	backoffMax time.Duration
+
+	// client คือ http.Client ที่ใช้ส่ง request, NewFetcher สร้างให้ถ้าไม่ได้กำหนด
+	client *http.Client
+
+	// transport ถูกใช้เป็น transport ของ client โดยตรง และทำให้ transportOpts ถูกข้าม
+	transport *http.Transport
+
+	// transportOpts ปรับค่า transport ของ client (เช่น proxy) ตามลำดับ, nil หมายถึงใช้ transport เดิม
+	transportOpts []func(*http.Transport)
+
+	// insecureSkipVerify และ rootCAs บอกว่าตั้งค่า TLS อะไรไว้ ใช้เพื่อเตือนผ่าน logger
+	insecureSkipVerify bool
+	rootCAs            bool
+
+	// jar เก็บ cookie ข้าม request ของ Fetcher เดียวกัน (nil หมายถึงไม่เก็บ cookie)
+	jar http.CookieJar
+
+	// checkRedirect จำกัดจำนวน redirect ตาม WithMaxRedirects (nil หมายถึงใช้ค่าของ client)
+	checkRedirect func(*http.Request, []*http.Request) error
+
+	// clientPerHost ใช้ client แยกต่อ host และ hostClients ถูกสร้างครั้งเดียวใน NewFetcher
+	clientPerHost bool
+	hostClients   *hostClients
+
+	// noRedirects ปิดการตาม redirect และถือว่า response 3xx ไม่ใช่ error
+	noRedirects bool
+
+	// headers คือ header ที่ใส่ให้ทุก request, header ของ Request แต่ละตัวมีลำดับความสำคัญสูงกว่า
+	headers http.Header
+
+	// auth ใส่ Authorization ให้ request ที่ยังไม่มี (จาก WithBearerToken หรือ WithBasicAuth)
+	auth func(*http.Request)
+
+	// userAgent ใส่เป็น User-Agent ให้ request ที่ยังไม่มี ("" หมายถึงใช้ค่าเริ่มต้นของ Go)
+	userAgent string
+
+	// beforeRequest ถูกเรียกกับ request ทุกครั้งก่อนส่ง ตามลำดับที่กำหนด
+	beforeRequest []func(*http.Request) error
+
+	// afterResponse ถูกเรียกกับ response ทุกครั้งก่อนอ่าน body ตามลำดับที่กำหนด
+	afterResponse []func(*http.Response) error
+
+	// signer ลงชื่อ request ทุกครั้งก่อนส่ง (nil หมายถึงไม่ลงชื่อ)
+	signer Signer
+
+	// correlationID ดึง correlation ID จาก context ของการเรียก (nil หมายถึงไม่ดึง)
+	correlationID func(context.Context) string
+
+	// validator ตรวจ response หลังอ่าน body แล้ว (nil หมายถึงไม่ตรวจ)
+	validator func(APIResult) error
+
+	// onError ถูกเรียกกับผลลัพธ์ที่ล้มเหลว (nil หมายถึงไม่เรียก)
+	onError func(APIResult)
+
+	// maxBodyBytes จำกัดขนาด response body ที่จะอ่านเข้าหน่วยความจำ
+	// ถ้า body ใหญ่กว่านี้จะได้ Error และ Body ที่ถูกตัดไว้ที่ maxBodyBytes, ค่า 0 หมายถึงไม่จำกัด
+	maxBodyBytes int64
+
+	// bodyWriter เปิด writer สำหรับเขียน body ของ response ที่สำเร็จ (nil หมายถึงเก็บใน Body)
+	bodyWriter func(url string) (io.Writer, error)
+
+	// bodyHash สร้าง hash สำหรับคำนวณ checksum ของ body (nil หมายถึงไม่คำนวณ)
+	bodyHash func() hash.Hash
+
+	// compressRequests บีบอัด body ของ request ที่ใหญ่กว่า RequestCompressionThreshold ด้วย gzip
+	compressRequests bool
+
+	// skipStatusCheck ถือว่าทุก status code สำเร็จ (ไม่ตั้ง Error และไม่ลองใหม่ตาม status)
+	skipStatusCheck bool
+
+	// streamBody ส่ง body ของ response ที่สำเร็จผ่าน APIResult.BodyReader แทนการอ่านเข้า Body
+	// ถูกตั้งโดย FetchReader เท่านั้น
+	streamBody bool
+
+	// detailedTiming เก็บเวลาของแต่ละช่วงใน request ผ่าน httptrace
+	detailedTiming bool
+
+	// disableDecompression ปิดการถอด gzip/deflate ตาม Content-Encoding ของ response
+	disableDecompression bool
+
+	// rateLimit จำกัดจำนวน request ต่อวินาทีของการเรียกหนึ่งครั้ง (ใช้ร่วมกันทุก goroutine)
+	// ทุกการส่ง request รวมถึงการลองใหม่ต้องรอคิวก่อน, ค่า 0 หมายถึงไม่จำกัด
+	rateLimit float64
+
+	// logger ถูกเรียกในแต่ละช่วงของ request (nil หมายถึงไม่เรียก)
+	logger func(event string, result APIResult)
+
+	// maxPages จำกัดจำนวนหน้าของ FetchPaginated (ค่า <= 0 หมายถึงใช้ DefaultMaxPages)
+	maxPages int
+
+	// progress ถูกเรียกทุกครั้งที่ request ในชุดทำงานเสร็จ (nil หมายถึงไม่เรียก)
+	progress func(completed, total int)
+
+	// metrics รับค่าสถิติของแต่ละ request (ค่าเริ่มต้นคือ noopMetrics ที่ไม่ทำอะไร)
+	metrics Metrics
+
+	// tracer สร้าง span ของแต่ละ request (ค่าเริ่มต้นคือ noopTracer ที่ไม่ทำอะไร)
+	tracer Tracer
+
+	// breakerThreshold และ breakerCooldown กำหนด circuit breaker ของแต่ละ host (threshold <= 0 หมายถึงปิด)
+	breakerThreshold int
+	breakerCooldown  time.Duration
+
+	// breaker ถูกสร้างครั้งเดียวใน NewFetcher เพื่อให้ทุกการเรียกของ Fetcher ใช้สถานะร่วมกัน
+	breaker *circuitBreaker
+
+	// etagCache เปิดการส่ง request แบบมีเงื่อนไขด้วย ETag/Last-Modified
+	// และ etags ถูกสร้างครั้งเดียวใน NewFetcher เพื่อให้ทุกการเรียกของ Fetcher ใช้ cache ร่วมกัน
+	etagCache bool
+	etags     *etagCache
+
+	// cacheTTL คืออายุของผลลัพธ์ใน cache (0 หมายถึงปิด) และ cache ถูกสร้างครั้งเดียวใน NewFetcher
+	cacheTTL time.Duration
+	cache    *responseCache
+
+	// limiter ถูกสร้างจาก rateLimit เมื่อเริ่มการเรียกแต่ละครั้ง
+	limiter *rateLimiter
+
+	// retryBudgetSize คือจำนวนการลองใหม่รวมต่อการเรียกหนึ่งครั้ง (0 หมายถึงไม่จำกัด)
+	// และ retryBudget ถูกสร้างจาก retryBudgetSize เมื่อเริ่มการเรียกแต่ละครั้ง
+	retryBudgetSize int
+	retryBudget     *retryBudget
+
+	// lifecycle ถูกสร้างครั้งเดียวใน NewFetcher เพื่อให้ Shutdown รอ request ของทุกการเรียกได้
+	lifecycle *lifecycle
+}
+
+// Option ปรับแต่งการดึงข้อมูล ใช้ส่งให้ NewFetcher, Fetch, FetchAll และฟังก์ชันอื่นๆ ในแพ็กเกจ
+type Option func(*config)
+
+// newConfig สร้าง config จากค่าเริ่มต้น แล้วปรับตาม opts ตามลำดับ
+// ค่าเริ่มต้นให้ผลเหมือนกับ FetchAll เดิม: timeout 10 วินาที ไม่ลองใหม่ และไม่จำกัดจำนวน
+func newConfig(opts []Option) config {
+	cfg := config{timeout: DefaultTimeout, metrics: noopMetrics{}, tracer: noopTracer{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithTimeout กำหนด timeout ของแต่ละ request (ค่าเริ่มต้นคือ DefaultTimeout)
+// ค่า 0 หมายถึงไม่มี timeout, timeout มีผลเสมอแม้ใช้ WithClient เพราะกำหนดผ่าน context ของ request
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// ErrSlowResponse คือ error ของ request ที่สำเร็จแต่ใช้เวลานานกว่าที่ WithMaxResponseTime กำหนด
+// ตรวจได้ด้วย errors.Is(result.Error, ErrSlowResponse)
+var ErrSlowResponse = errors.New("response too slow")
+
+// WithMaxResponseTime ถือว่า request ที่สำเร็จแต่ใช้เวลา (Latency) เกิน d ล้มเหลวด้วย ErrSlowResponse
+// ต่างจาก WithTimeout ที่ยกเลิก request กลางทาง เพราะ request นี้ทำงานเสร็จแล้ว
+// Body และ StatusCode จึงยังอยู่ในผลลัพธ์ให้ตรวจดูได้ และไม่ลองใหม่, ค่า 0 หมายถึงไม่จำกัด
+func WithMaxResponseTime(d time.Duration) Option {
+	return func(c *config) {
+		c.maxResponseTime = d
+	}
+}
+
+// WithRetries ให้ลองส่ง request ใหม่ได้อีกไม่เกิน n ครั้งหลังครั้งแรก
+// จะลองใหม่เมื่อเกิด network error หรือ status ที่ตรงกับ WithRetryOn (ค่าเริ่มต้นคือ 5xx และ 429)
+func WithRetries(n int) Option {
+	return func(c *config) {
+		c.maxAttempts = n + 1
+	}
+}
+
+// WithBackoff กำหนดเวลารอระหว่างการลองใหม่แบบ exponential: base, 2*base, 4*base, ...
+// ไม่เกิน max (ค่า 0 หมายถึงใช้ DefaultMaxBackoff) และสุ่มลดลงเล็กน้อยเพื่อกระจายการลองใหม่
+func WithBackoff(base, max time.Duration) Option {
+	return func(c *config) {
+		c.backoffBase = base
+		c.backoffMax = max
+	}
+}
+
+// WithHeader เพิ่ม header ให้ทุก request ถ้า Request มี header key เดียวกันจะใช้ค่าของ Request แทน
+func WithHeader(key, value string) Option {
+	return func(c *config) {
+		if c.headers == nil {
+			c.headers = make(http.Header)
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithHeaders เพิ่มทุก header ใน h ให้ทุก request เหมือนการเรียก WithHeader ทีละค่า
+// เช่น Accept: application/json, ถ้า Request มี header key เดียวกันจะใช้ค่าของ Request แทน
+func WithHeaders(h http.Header) Option {
+	return func(c *config) {
+		for key, values := range h {
+			for _, value := range values {
+				WithHeader(key, value)(c)
+			}
+		}
+	}
+}
+
+// WithUserAgent กำหนด User-Agent ให้ทุก request แทนค่าเริ่มต้นของ Go
+// ถ้า request มี User-Agent อยู่แล้ว (จาก Request.Headers หรือ WithHeader) จะไม่ทับค่านั้น
+func WithUserAgent(ua string) Option {
+	return func(c *config) {
+		c.userAgent = ua
+	}
+}
+
+// WithClient ใช้ client ที่กำหนดแทนการสร้างใหม่ เช่น เพื่อกำหนด transport, proxy หรือ connection pool เอง
+// ใช้ client เดียวกันซ้ำได้ในหลายการเรียกเพื่อ reuse connection
+func WithClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+// WithMaxBody จำกัดขนาด response body (หลังถอดการบีบอัด) ไม่เกิน n bytes
+// ถ้า body ใหญ่กว่านี้ผลลัพธ์จะมี Error และ Body ที่ถูกตัดไว้ที่ n bytes, ค่า 0 หมายถึงไม่จำกัด
+func WithMaxBody(n int64) Option {
+	return func(c *config) {
+		c.maxBodyBytes = n
+	}
+}
+
+// WithConcurrency จำกัดจำนวน request ที่ทำงานพร้อมกันไม่เกิน n ด้วย worker pool
+// ค่า <= 0 หมายถึงไม่จำกัด (หนึ่ง goroutine ต่อหนึ่ง URL)
+//
+// ใช้ร่วมกับ WithRateLimit และ WithRetries ได้: URL หนึ่งใช้ worker ตัวเดียวตลอดทุกครั้งที่ลองใหม่
+// การลองใหม่จึงไม่แย่ง slot ของ URL อื่นและไม่ทำให้เกิน n, ส่วน rate limiter ควบคุมทุกครั้งที่ส่งจริง
+// (รวมการลองใหม่) ระหว่างรอ backoff หรือรอคิว rate limiter worker ยังถือ slot ไว้ URL ถัดไปจึงรอก่อน
+func WithConcurrency(n int) Option {
+	return func(c *config) {
+		c.maxWorkers = n
+	}
+}
+
+// WithRateLimit จำกัดจำนวน request ต่อวินาที โดยใช้ร่วมกันทุก goroutine ในการเรียกครั้งเดียว
+// ทุกการส่ง request รวมถึงการลองใหม่ต้องรอคิวก่อน, ค่า 0 หมายถึงไม่จำกัด
+func WithRateLimit(perSecond float64) Option {
+	return func(c *config) {
+		c.rateLimit = perSecond
+	}
+}
+
+// WithoutDecompression ปิดการถอด gzip/deflate ตาม Content-Encoding สำหรับผู้ที่ต้องการ byte ดิบ
+// หมายเหตุ: ถ้า request ไม่ได้กำหนด Accept-Encoding เอง transport ของ Go
+// อาจขอ gzip และถอดให้ก่อนแล้ว
+func WithoutDecompression() Option {
+	return func(c *config) {
+		c.disableDecompression = true
+	}
+}
+
+// WithSkipStatusCheck อ่านและคืน body ของทุก status code โดยไม่ตั้ง Error ให้ผู้เรียกแยกกรณีจาก StatusCode เอง
+// เช่น เมื่อ 404 เป็นผลลัพธ์ปกติ ("ไม่พบข้อมูล") แทนการแกะ status จากข้อความ error
+// เนื่องจากไม่มี Error จึงไม่ลองใหม่ตาม status (network error ยังลองใหม่ตามปกติ)
+// Successful และ Failed ยังแยกตาม 2xx เหมือนเดิม, ถ้าไม่กำหนดจะถือว่าเฉพาะ 2xx สำเร็จ
+func WithSkipStatusCheck() Option {
+	return func(c *config) {
+		c.skipStatusCheck = true
+	}
+}
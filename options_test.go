@@ -0,0 +1,16 @@
+package goroutine
+
+import "testing"
+
+func TestWithUserAgent(t *testing.T) {
+	server := headerServer("User-Agent")
+	defer server.Close()
+
+	result := Fetch(server.URL, WithUserAgent("my-crawler/1.0"))
+	if result.Error != nil {
+		t.Fatalf("Fetch error: %v", result.Error)
+	}
+	if got := string(result.Body); got != "my-crawler/1.0" {
+		t.Errorf("User-Agent = %q, want %q", got, "my-crawler/1.0")
+	}
+}
@@ -0,0 +1,74 @@
+package goroutine
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// DefaultMaxPages คือจำนวนหน้าสูงสุดที่ FetchPaginated ดึงเมื่อไม่ได้กำหนด WithMaxPages
+const DefaultMaxPages = 100
+
+// WithMaxPages จำกัดจำนวนหน้าที่ FetchPaginated ดึงได้ไม่เกิน n (ค่า <= 0 หมายถึงใช้ DefaultMaxPages)
+func WithMaxPages(n int) Option {
+	return func(c *config) {
+		c.maxPages = n
+	}
+}
+
+// FetchPaginated ดึงหน้าแรกจาก startURL แล้วดึงหน้าถัดไปทีละหน้า ตราบที่ nextFn คืน URL ที่ไม่ว่างและ true
+// nextFn รับ Body ของหน้าล่าสุด เช่น เพื่ออ่าน field "next" จาก JSON, URL แบบ relative จะอ้างอิงจากหน้าล่าสุด
+// ผลลัพธ์ของทุกหน้าที่ดึงแล้วถูกคืนตามลำดับ และ error ไม่เป็น nil เมื่อ:
+// หน้าใดล้มเหลว (ผลลัพธ์ของหน้านั้นอยู่ท้าย slice), ได้ URL ที่เคยดึงแล้ว (วนลูป)
+// หรือยังมีหน้าถัดไปแต่ดึงครบ WithMaxPages แล้ว
+func FetchPaginated(startURL string, nextFn func(body []byte) (string, bool), opts ...Option) ([]APIResult, error) {
+	return NewFetcher(opts...).FetchPaginated(startURL, nextFn)
+}
+
+// FetchPaginated ทำงานเหมือนฟังก์ชัน FetchPaginated แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchPaginated(startURL string, nextFn func(body []byte) (string, bool)) ([]APIResult, error) {
+	maxPages := f.cfg.maxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+
+	var results []APIResult
+	visited := make(map[string]bool)
+	for current := startURL; ; {
+		visited[current] = true
+		result := fetch(context.Background(), Request{URL: current}, f.cfg)
+		results = append(results, result)
+		if result.Error != nil {
+			return results, fmt.Errorf("fetching page %d (%s): %w", len(results), current, result.Error)
+		}
+
+		next, ok := nextFn(result.Body)
+		if !ok || next == "" {
+			return results, nil
+		}
+		next, err := resolveURL(current, next)
+		if err != nil {
+			return results, fmt.Errorf("invalid next page URL after page %d: %w", len(results), err)
+		}
+		if visited[next] {
+			return results, fmt.Errorf("pagination loop: %s was already fetched", next)
+		}
+		if len(results) >= maxPages {
+			return results, fmt.Errorf("pagination stopped after %d pages: more pages remain", maxPages)
+		}
+		current = next
+	}
+}
+
+// resolveURL แปลง ref ให้เป็น URL แบบเต็มโดยอ้างอิงจาก base
+func resolveURL(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.ResolveReference(refURL).String(), nil
+}
@@ -0,0 +1,29 @@
+package goroutine
+
+import "sync"
+
+// WithProgress เรียก progress ทุกครั้งที่ request ในชุดทำงานเสร็จ พร้อมจำนวนที่เสร็จแล้วและจำนวนทั้งหมด
+// เช่น เพื่อแสดง progress bar, progress ไม่ถูกเรียกพร้อมกันและ completed เพิ่มขึ้นทีละหนึ่งเสมอ
+// จึงไม่ต้องป้องกันการใช้งานพร้อมกันเอง แต่ควรทำงานเร็วเพราะ request อื่นที่เสร็จต้องรอ
+// ใช้กับการเรียกที่ส่ง request หลายตัว เช่น FetchAll และ FetchRequests
+func WithProgress(progress func(completed, total int)) Option {
+	return func(c *config) {
+		c.progress = progress
+	}
+}
+
+// withProgress ห่อ deliver ให้เรียก progress หลังส่งผลลัพธ์แต่ละตัว
+func withProgress(deliver func(int, APIResult), total int, progress func(completed, total int)) func(int, APIResult) {
+	var (
+		mu        sync.Mutex
+		completed int
+	)
+	return func(index int, result APIResult) {
+		deliver(index, result)
+
+		mu.Lock()
+		defer mu.Unlock()
+		completed++
+		progress(completed, total)
+	}
+}
@@ -0,0 +1,52 @@
+package goroutine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter ปล่อย request ออกไปไม่เกินอัตราที่กำหนด โดยเว้นระยะห่างเท่าๆ กัน
+// ใช้ร่วมกันได้จากหลาย goroutine
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // ระยะห่างระหว่าง request แต่ละตัว
+	next     time.Time     // เวลาที่ request ตัวถัดไปจะได้ส่ง
+}
+
+// newRateLimiter สร้าง rateLimiter ที่ปล่อย request ได้ perSecond ตัวต่อวินาที
+// คืน nil ถ้า perSecond <= 0 (ไม่จำกัด)
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait จองคิวถัดไปแล้วรอจนถึงเวลานั้น หรือจนกว่า ctx จะถูกยกเลิก
+// ถ้า l เป็น nil จะไม่รอ แต่ยังคืน ctx.Err() ถ้า ctx ถูกยกเลิกไปแล้ว
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, time.Until(at)); err != nil {
+		// ถูกยกเลิกก่อนถึงคิว คืนคิวนี้ถ้ายังไม่มีใครจองต่อ เพื่อไม่ให้ request อื่นต้องรอนานเกินจำเป็น
+		l.mu.Lock()
+		if l.next.Equal(at.Add(l.interval)) {
+			l.next = at
+		}
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ผู้เรียกที่ถูกยกเลิกระหว่างรอคิวคืนคิวของตัวเอง ผู้เรียกถัดไปจึงไม่ต้องรอเพิ่มอีกหนึ่งช่วง
+func TestRateLimiterCancelReturnsSlot(t *testing.T) {
+	const interval = 200 * time.Millisecond
+	limiter := newRateLimiter(float64(time.Second / interval))
+
+	start := time.Now()
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("cancelled wait error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if err := limiter.wait(context.Background()); err != nil {
+		t.Fatalf("third wait: %v", err)
+	}
+	// ถ้าคิวที่ถูกยกเลิกไม่ถูกคืน ครั้งที่สามจะได้คิวที่ 2*interval
+	if elapsed := time.Since(start); elapsed >= interval*3/2 {
+		t.Errorf("third wait finished after %v, want about %v", elapsed, interval)
+	}
+}
@@ -0,0 +1,115 @@
+package goroutine
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// Errors รวม Error ของทุกผลลัพธ์ที่ล้มเหลวเป็น error เดียวด้วย errors.Join
+// แต่ละ error ขึ้นต้นด้วย URL ของมัน และคืน nil ถ้าทุกผลลัพธ์สำเร็จ
+func Errors(results []APIResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.URL, result.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// allFailed คืน Errors(results) ถ้าทุกผลลัพธ์ล้มเหลว ไม่เช่นนั้น (รวมถึงเมื่อไม่มีผลลัพธ์เลย) คืน nil
+func allFailed(results []APIResult) error {
+	for _, result := range results {
+		if result.Error == nil {
+			return nil
+		}
+	}
+	return Errors(results)
+}
+
+// SortByLatency เรียง results ตาม Latency ในตัว slice เอง (เร็วไปช้าถ้า ascending เป็น true)
+// ผลลัพธ์ที่มี Error อยู่ท้ายสุดเสมอไม่ว่าจะเรียงทางใด เพราะ latency ของมันไม่ได้สะท้อนความเร็วจริง
+// ผลลัพธ์ที่ latency เท่ากัน (รวมถึงผลลัพธ์ที่มี Error) คงลำดับเดิมไว้
+func SortByLatency(results []APIResult, ascending bool) {
+	slices.SortStableFunc(results, func(a, b APIResult) int {
+		if failed := cmp.Compare(boolInt(a.Error != nil), boolInt(b.Error != nil)); failed != 0 || a.Error != nil {
+			return failed
+		}
+		if ascending {
+			return cmp.Compare(a.Latency, b.Latency)
+		}
+		return cmp.Compare(b.Latency, a.Latency)
+	})
+}
+
+// boolInt แปลง bool เป็น 0 หรือ 1 สำหรับใช้เปรียบเทียบ
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Successful คืนผลลัพธ์ที่สำเร็จ คือไม่มี Error และ StatusCode เป็น 2xx
+// หรือเป็น 304 ที่ได้ Body จาก WithETagCache (FromCache เป็น true) ตามลำดับเดิม
+// คืน slice ใหม่เสมอ results เดิมไม่ถูกแก้ไข
+func Successful(results []APIResult) []APIResult {
+	return filterResults(results, true)
+}
+
+// Failed คืนผลลัพธ์ที่ไม่ผ่านเงื่อนไขของ Successful ตามลำดับเดิม
+// รวมถึง response 3xx ที่ได้จาก WithNoRedirects ซึ่งไม่มี Error แต่ไม่ใช่ 2xx
+func Failed(results []APIResult) []APIResult {
+	return filterResults(results, false)
+}
+
+// filterResults คืนผลลัพธ์ที่ความสำเร็จตรงกับ success
+func filterResults(results []APIResult, success bool) []APIResult {
+	var out []APIResult
+	for _, result := range results {
+		if succeeded(result) == success {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// succeeded รายงานว่า result สำเร็จหรือไม่ คือไม่มี Error และ StatusCode เป็น 2xx
+// หรือเป็น 304 ที่ตอบ request แบบมีเงื่อนไขของ WithETagCache ซึ่งได้ Body จาก cache แล้ว
+func succeeded(result APIResult) bool {
+	if result.Error != nil {
+		return false
+	}
+	if result.FromCache && result.StatusCode == http.StatusNotModified {
+		return true
+	}
+	return result.StatusCode >= 200 && result.StatusCode < 300
+}
+
+// StatusClassTransportError คือ key ของ GroupByStatusClass สำหรับผลลัพธ์ที่ไม่ได้รับ response เลย
+// (network error, timeout, ถูกยกเลิก ฯลฯ) ซึ่ง StatusCode เป็น 0
+const StatusClassTransportError = 0
+
+// GroupByStatusClass นับผลลัพธ์ตามกลุ่มของ status code โดย key คือหลักร้อย (2 สำหรับ 2xx, 4 สำหรับ 4xx ฯลฯ)
+// และ StatusClassTransportError สำหรับผลลัพธ์ที่ไม่ได้รับ response ใช้ดูภาพรวมสุขภาพของหนึ่งชุด
+// กลุ่มที่ไม่มีผลลัพธ์จะไม่มี key ใน map
+func GroupByStatusClass(results []APIResult) map[int]int {
+	counts := make(map[int]int)
+	for _, result := range results {
+		counts[result.StatusCode/100]++
+	}
+	return counts
+}
+
+// TotalBytes รวมขนาด body ของทุกผลลัพธ์ สำหรับคิดปริมาณข้อมูลที่ดาวน์โหลดในหนึ่งชุด
+// ผลลัพธ์ที่เขียน body ลง writer ด้วย WithBodyWriter จะใช้ BytesWritten แทนความยาวของ Body
+func TotalBytes(results []APIResult) int64 {
+	var total int64
+	for _, result := range results {
+		total += bodySize(result)
+	}
+	return total
+}
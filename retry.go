@@ -0,0 +1,161 @@
+package goroutine
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRetryOn คือเงื่อนไขการลองใหม่ตาม status code ที่ใช้เมื่อไม่ได้กำหนด WithRetryOn
+// หรือ Request.RetryOn: ลองใหม่เมื่อเป็น 5xx หรือ 429 Too Many Requests
+func DefaultRetryOn(statusCode int) bool {
+	return statusCode >= 500 || statusCode == http.StatusTooManyRequests
+}
+
+// WithRetryOn กำหนดเงื่อนไขว่า status code ใดควรลองใหม่สำหรับทุก request
+// เช่น ให้ 503 ลองใหม่แต่ถือว่า 429 เป็นความล้มเหลวถาวร
+// network error ยังลองใหม่เสมอ และ Request.RetryOn มีลำดับความสำคัญสูงกว่า
+func WithRetryOn(predicate func(statusCode int) bool) Option {
+	return func(c *config) {
+		c.retryOn = predicate
+	}
+}
+
+// retryOn เลือกเงื่อนไขการลองใหม่ของ r ตามลำดับ: Request.RetryOn, WithRetryOn, DefaultRetryOn
+func retryOn(r Request, cfg config) func(statusCode int) bool {
+	if r.RetryOn != nil {
+		return r.RetryOn
+	}
+	if cfg.retryOn != nil {
+		return cfg.retryOn
+	}
+	return DefaultRetryOn
+}
+
+// WithOnRetry เรียก callback ก่อนรอลองใหม่ทุกครั้ง (ไม่ถูกเรียกกับความพยายามครั้งแรก) เพื่อ log หรือนับ metrics ของการลองใหม่
+// attempt คือครั้งที่กำลังจะส่ง (2 สำหรับการลองใหม่ครั้งแรก), err คือ error ของครั้งก่อนหน้า
+// และ nextDelay คือเวลาที่จะรอก่อนส่ง (backoff หรือ Retry-After)
+// callback อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+func WithOnRetry(callback func(url string, attempt int, err error, nextDelay time.Duration)) Option {
+	return func(c *config) {
+		c.onRetry = callback
+	}
+}
+
+// WithRetryBudget จำกัดจำนวนการลองใหม่รวมของทุก URL ในการเรียกหนึ่งครั้ง (เช่น หนึ่ง FetchAll หรือหนึ่ง stream)
+// ไม่เกิน n ครั้ง เมื่อใช้หมดแล้ว URL ที่ล้มเหลวต่อจากนั้นจะไม่ลองใหม่อีก แม้ยังไม่ครบ WithRetries
+// เพื่อไม่ให้ชุดขนาดใหญ่ระดม request ซ้ำใส่ downstream ที่ล่มอยู่ ทุกครั้งที่ข้ามการลองใหม่เพราะหมด budget
+// จะส่ง EventRetryBudgetExhausted ให้ logger, ค่า n <= 0 หมายถึงไม่จำกัด
+func WithRetryBudget(n int) Option {
+	return func(c *config) {
+		c.retryBudgetSize = n
+	}
+}
+
+// retryBudget นับจำนวนการลองใหม่ที่เหลือของการเรียกหนึ่งครั้ง ใช้ร่วมกันได้จากหลาย goroutine
+type retryBudget struct {
+	remaining atomic.Int64
+}
+
+// newRetryBudget สร้าง retryBudget ที่ลองใหม่ได้ n ครั้ง คืน nil ถ้า n <= 0 (ไม่จำกัด)
+func newRetryBudget(n int) *retryBudget {
+	if n <= 0 {
+		return nil
+	}
+	b := &retryBudget{}
+	b.remaining.Store(int64(n))
+	return b
+}
+
+// take ใช้ budget หนึ่งครั้ง คืน false ถ้าหมดแล้ว ถ้า b เป็น nil จะคืน true เสมอ
+func (b *retryBudget) take() bool {
+	return b == nil || b.remaining.Add(-1) >= 0
+}
+
+// DefaultMaxBackoff คือเวลารอสูงสุดระหว่างการลองใหม่เมื่อไม่ได้กำหนดค่า max ใน WithBackoff
+const DefaultMaxBackoff = 30 * time.Second
+
+// backoff คำนวณเวลารอก่อนลองใหม่หลังจากความพยายามครั้งที่ attempt (เริ่มที่ 1)
+// ได้ค่า base * 2^(attempt-1) ไม่เกิน backoffMax แล้วสุ่มลดลงไม่เกิน 20%
+// เพื่อไม่ให้ goroutine หลายตัวลองใหม่พร้อมกัน (thundering herd)
+func backoff(attempt int, cfg config) time.Duration {
+	if cfg.backoffBase <= 0 {
+		return 0
+	}
+	maxDelay := maxBackoff(cfg)
+
+	delay := cfg.backoffBase
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// jitter
+	if jitter := delay / 5; jitter > 0 {
+		delay -= rand.N(jitter)
+	}
+	return delay
+}
+
+// maxBackoff คือเวลารอสูงสุดระหว่างการลองใหม่ตาม WithBackoff หรือ DefaultMaxBackoff
+func maxBackoff(cfg config) time.Duration {
+	if cfg.backoffMax > 0 {
+		return cfg.backoffMax
+	}
+	return DefaultMaxBackoff
+}
+
+// retryDelay คำนวณเวลารอก่อนลองใหม่หลังจากความพยายามครั้งที่ attempt ซึ่งได้ result
+// ถ้า server ตอบ 429 หรือ 503 พร้อม header Retry-After จะรอตามนั้น (ไม่เกินเวลารอสูงสุดของ backoff)
+// ไม่เช่นนั้นใช้ exponential backoff ตามปกติ
+func retryDelay(attempt int, result APIResult, cfg config) time.Duration {
+	if result.StatusCode == http.StatusTooManyRequests || result.StatusCode == http.StatusServiceUnavailable {
+		if delay, ok := parseRetryAfter(result.Headers.Get("Retry-After"), time.Now()); ok {
+			return min(delay, maxBackoff(cfg))
+		}
+	}
+	return backoff(attempt, cfg)
+}
+
+// parseRetryAfter อ่านค่า Retry-After ได้ทั้งแบบจำนวนวินาที ("120") และแบบวันที่ HTTP
+// ("Wed, 21 Oct 2015 07:28:00 GMT") วันที่ที่ผ่านไปแล้วได้ 0, คืน false ถ้าไม่มีหรืออ่านไม่ได้
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(at.Sub(now), 0), true
+}
+
+// sleepContext รอเป็นเวลา d หรือจนกว่า ctx จะถูกยกเลิก
+// คืน ctx.Err() ถ้าถูกยกเลิกก่อนครบเวลา ทุกการรอในแพ็กเกจ (backoff, Retry-After, rate limiter)
+// ต้องผ่านฟังก์ชันนี้แทน time.Sleep เพื่อให้การยกเลิกมีผลทันที
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,49 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// sleepContext คืนทันทีพร้อม ctx.Err() เมื่อ ctx ถูกยกเลิกระหว่างรอ
+func TestSleepContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := sleepContext(ctx, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("sleepContext error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("sleepContext returned after %v, want prompt return", elapsed)
+	}
+}
+
+// การยกเลิก ctx ระหว่างรอ backoff ทำให้ Fetch คืนทันทีพร้อม error ของ ctx แทนการรอจนครบเวลา
+func TestFetchCancelDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	f := NewFetcher(WithRetries(3), WithBackoff(time.Minute, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	result := f.FetchContext(ctx, server.URL)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("FetchContext returned after %v, want prompt return", elapsed)
+	}
+	if !errors.Is(result.Error, context.Canceled) {
+		t.Errorf("result error = %v, want context.Canceled", result.Error)
+	}
+	if len(result.Attempts) != 1 {
+		t.Errorf("attempts = %d, want 1", len(result.Attempts))
+	}
+}
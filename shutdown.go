@@ -0,0 +1,82 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrFetcherClosed คือ error ของ request ที่เริ่มหลังจากเรียก Fetcher.Shutdown แล้ว
+// ตรวจได้ด้วย errors.Is(result.Error, ErrFetcherClosed)
+var ErrFetcherClosed = errors.New("fetcher is shut down")
+
+// Shutdown หยุดรับงานใหม่แล้วรอให้ request ที่กำลังทำงานอยู่เสร็จ สำหรับ Fetcher ที่ฝังอยู่ใน service ที่ทำงานยาว
+// request ที่เริ่มหลังจากนี้ (รวม URL ที่ยังรอคิวในชุดที่กำลังทำงาน) จะได้ ErrFetcherClosed ทันที
+// ถ้า ctx หมดเวลาก่อน request จะถูกยกเลิก (ได้ Error จาก context) และ Shutdown คืน ctx.Err()
+// โดยไม่รอให้ goroutine ที่ถูกยกเลิกจบ, เรียกซ้ำได้และรอ request ที่เหลือเหมือนเดิม
+func (f *Fetcher) Shutdown(ctx context.Context) error {
+	return f.cfg.lifecycle.shutdown(ctx)
+}
+
+// lifecycle ติดตาม request ที่กำลังทำงานของ Fetcher เพื่อให้ Shutdown รอหรือยกเลิกได้
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+
+	// stopped ถูกยกเลิกเมื่อ Shutdown หมดเวลา เพื่อยกเลิก request ที่ยังทำงานอยู่ทั้งหมด
+	stopped context.Context
+	stop    context.CancelFunc
+}
+
+// newLifecycle สร้าง lifecycle ของ Fetcher ใหม่
+func newLifecycle() *lifecycle {
+	stopped, stop := context.WithCancel(context.Background())
+	return &lifecycle{stopped: stopped, stop: stop}
+}
+
+// enter ลงทะเบียน request ที่กำลังจะเริ่ม และคืน context ที่ถูกยกเลิกเมื่อ Shutdown หมดเวลา
+// ต้องเรียก done เมื่อ request จบ, คืน ErrFetcherClosed ถ้า Fetcher ถูกปิดแล้ว
+// ถ้า l เป็น nil จะคืน ctx เดิม
+func (l *lifecycle) enter(ctx context.Context) (context.Context, func(), error) {
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return ctx, nil, ErrFetcherClosed
+	}
+	l.inflight.Add(1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	unregister := context.AfterFunc(l.stopped, cancel)
+	return ctx, func() {
+		unregister()
+		cancel()
+		l.inflight.Done()
+	}, nil
+}
+
+// shutdown ปิดรับ request ใหม่แล้วรอ request ที่เหลือจนเสร็จหรือ ctx หมดเวลา
+func (l *lifecycle) shutdown(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		l.inflight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		l.stop()
+		return ctx.Err()
+	}
+}
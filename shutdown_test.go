@@ -0,0 +1,86 @@
+package goroutine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// slowServer ตอบหลังจาก delay หรือเมื่อ client ยกเลิก request และส่งสัญญาณทาง started เมื่อได้รับ request
+func slowServer(delay time.Duration, started chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(delay):
+			w.Write([]byte("ok"))
+		}
+	}))
+}
+
+// Shutdown รอ request ที่กำลังทำงานจนเสร็จ แล้ว request ใหม่จะได้ ErrFetcherClosed
+func TestShutdownDrainsInFlight(t *testing.T) {
+	started := make(chan struct{}, 2)
+	server := slowServer(200*time.Millisecond, started)
+	defer server.Close()
+
+	f := NewFetcher()
+	done := make(chan []APIResult)
+	go func() {
+		results, _ := f.FetchAll([]string{server.URL, server.URL})
+		done <- results
+	}()
+	<-started
+	<-started
+
+	if err := f.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown error: %v", err)
+	}
+	// Shutdown คืนค่าหลังจาก request ทั้งหมดเสร็จแล้ว ผลลัพธ์จึงต้องพร้อมทันที
+	select {
+	case results := <-done:
+		for _, result := range results {
+			if result.Error != nil || string(result.Body) != "ok" {
+				t.Errorf("in-flight result: error %v, body %q; want drained successfully", result.Error, result.Body)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FetchAll did not return after Shutdown")
+	}
+
+	if result := f.Fetch(server.URL); !errors.Is(result.Error, ErrFetcherClosed) {
+		t.Errorf("Fetch after Shutdown error = %v, want ErrFetcherClosed", result.Error)
+	}
+}
+
+// ถ้า ctx ของ Shutdown หมดเวลาก่อน request ที่ยังทำงานอยู่จะถูกยกเลิก
+func TestShutdownDeadlineCancelsInFlight(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := slowServer(time.Minute, started)
+	defer server.Close()
+
+	f := NewFetcher(WithTimeout(0))
+	done := make(chan APIResult)
+	go func() {
+		done <- f.Fetch(server.URL)
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := f.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown error = %v, want context.DeadlineExceeded", err)
+	}
+
+	select {
+	case result := <-done:
+		if !errors.Is(result.Error, context.Canceled) {
+			t.Errorf("in-flight result error = %v, want context.Canceled", result.Error)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("in-flight request was not cancelled")
+	}
+}
@@ -0,0 +1,34 @@
+package goroutine
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Signer ลงชื่อ request ก่อนส่ง เช่น ใส่ header ลายเซ็น HMAC ที่คำนวณจาก method, path, body และเวลา
+// Sign อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+// ถ้าต้องอ่าน body ให้ใช้ req.GetBody เพื่อไม่ให้ body ที่จะส่งถูกอ่านไปก่อน
+type Signer interface {
+	Sign(req *http.Request) error
+}
+
+// WithSigner ลงชื่อทุก request ที่ส่งออกไปด้วย signer รวมถึงทุกครั้งที่ลองใหม่
+// เพราะลายเซ็นมักมี timestamp ที่หมดอายุได้ โดยถูกเรียกหลังใส่ header, auth และ hook ของ WithBeforeRequest
+// แล้ว (ลายเซ็นจึงครอบคลุม request ที่จะส่งจริง) และก่อนส่งทันที
+// ถ้า Sign คืน error จะไม่ส่ง request และ error นั้นจะเป็น APIResult.Error (ไม่ลองใหม่)
+func WithSigner(signer Signer) Option {
+	return func(c *config) {
+		c.signer = signer
+	}
+}
+
+// signRequest ลงชื่อ req ด้วย Signer ของ WithSigner ถ้ามีการกำหนดไว้
+func signRequest(req *http.Request, cfg config) error {
+	if cfg.signer == nil {
+		return nil
+	}
+	if err := cfg.signer.Sign(req); err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+	return nil
+}
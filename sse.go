@@ -0,0 +1,114 @@
+package goroutine
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// FetchSSE เชื่อมต่อ endpoint แบบ server-sent events (text/event-stream) ค้างไว้
+// แล้วเรียก onEvent ทันทีที่ได้รับแต่ละ event แทนการรอเก็บ body ทั้งหมด
+// event คือค่าจาก field "event:" ("message" ถ้าไม่ได้กำหนด) และ data คือ field "data:" ทุกบรรทัดต่อกันด้วย "\n"
+// ทำงานจนกว่า ctx จะถูกยกเลิก (คืน ctx.Err()) หรือ server ปิด stream (คืน nil)
+// ใช้ header, auth และ hook ของ Fetcher แต่ไม่ใช้ WithTimeout และไม่ลองใหม่หรือเชื่อมต่อใหม่เมื่อหลุด
+// ถ้า WithClient ส่ง client ที่มี Timeout มา stream จะถูกตัดเมื่อครบเวลานั้น
+func FetchSSE(ctx context.Context, url string, onEvent func(event, data string), opts ...Option) error {
+	return NewFetcher(opts...).FetchSSE(ctx, url, onEvent)
+}
+
+// FetchSSE ทำงานเหมือนฟังก์ชัน FetchSSE แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchSSE(ctx context.Context, url string, onEvent func(event, data string)) error {
+	cfg := f.cfg
+	ctx, done, err := cfg.lifecycle.enter(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	applyHeaders(req, nil, cfg)
+	cfg.tracer.Inject(ctx, req.Header)
+	if err := runBeforeRequest(req, cfg); err != nil {
+		return fmt.Errorf("before request hook: %w", err)
+	}
+	if err := signRequest(req, cfg); err != nil {
+		return err
+	}
+
+	resp, err := cfg.clientFor(req.URL.Host).Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := runAfterResponse(resp, cfg); err != nil {
+		return fmt.Errorf("after response hook: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if err := checkContentType("text/event-stream", resp.Header.Get("Content-Type")); err != nil {
+		return err
+	}
+
+	if err := readEvents(resp.Body, onEvent); err != nil {
+		// การอ่านที่หยุดเพราะ ctx ถูกยกเลิกไม่ใช่ความผิดพลาดของ stream
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("error reading event stream: %w", err)
+	}
+	return nil
+}
+
+// readEvents อ่าน event stream จาก r ตามรูปแบบของ server-sent events แล้วส่งแต่ละ event ให้ onEvent
+// event จบที่บรรทัดว่าง, บรรทัดที่ขึ้นต้นด้วย ":" คือ comment และ field อื่นนอกจาก event/data ถูกข้าม
+// event ที่ยังไม่จบเมื่อ stream ปิดจะถูกทิ้ง คืน nil เมื่ออ่านถึง EOF
+func readEvents(r io.Reader, onEvent func(event, data string)) error {
+	reader := bufio.NewReader(r)
+	var (
+		event string
+		data  []string
+	)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			// บรรทัดว่างส่ง event ที่สะสมไว้ event ที่ไม่มี data ไม่ถูกส่ง
+			if data != nil {
+				if event == "" {
+					event = "message"
+				}
+				onEvent(event, strings.Join(data, "\n"))
+			}
+			event, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+}
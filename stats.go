@@ -0,0 +1,52 @@
+package goroutine
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// LatencyStats สรุป latency และจำนวนผลลัพธ์ที่สำเร็จ/ล้มเหลวของ request หลายตัว
+type LatencyStats struct {
+	Count   int // จำนวนผลลัพธ์ทั้งหมด
+	Success int // จำนวนผลลัพธ์ที่ไม่มี Error
+	Failure int // จำนวนผลลัพธ์ที่มี Error
+
+	// ค่าด้านล่างคำนวณจากผลลัพธ์ที่สำเร็จเท่านั้น (เป็น 0 ถ้าไม่มีผลลัพธ์ที่สำเร็จ)
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+	P95  time.Duration
+}
+
+// Stats สรุป latency ของ results ในการเรียกครั้งเดียว
+// ผลลัพธ์ที่มี Error จะนับใน Failure แต่ไม่นำมาคิด latency
+// เพราะ latency ของ request ที่ล้มเหลว (เช่น timeout) ไม่ได้สะท้อนความเร็วจริงของ API
+func Stats(results []APIResult) LatencyStats {
+	stats := LatencyStats{Count: len(results)}
+
+	latencies := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		if result.Error != nil {
+			stats.Failure++
+			continue
+		}
+		stats.Success++
+		latencies = append(latencies, result.Latency)
+	}
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	slices.Sort(latencies)
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+	stats.Min = latencies[0]
+	stats.Max = latencies[len(latencies)-1]
+	stats.Mean = total / time.Duration(len(latencies))
+	// ใช้วิธี nearest-rank: ค่าที่อันดับ ceil(0.95 * n)
+	stats.P95 = latencies[int(math.Ceil(0.95*float64(len(latencies))))-1]
+	return stats
+}
@@ -0,0 +1,139 @@
+package goroutine
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// FetchAllStream ดึงข้อมูลจากทุก URL พร้อมกัน และเรียก cb ทันทีที่แต่ละ request ทำงานเสร็จ
+// แทนการรอผลลัพธ์ทั้งชุด ลำดับการเรียก cb เป็นไปตามลำดับที่ทำงานเสร็จ
+//
+// cb ถูกเรียกจาก goroutine ของผู้เรียก FetchAllStream เพียงตัวเดียว จึงไม่ถูกเรียกพร้อมกัน
+// และไม่ต้องใช้ lock ภายใน cb, ระหว่างที่ cb ทำงาน goroutine ที่ทำเสร็จแล้วจะรอส่งผลลัพธ์
+// ฟังก์ชันนี้จะคืนค่าเมื่อทุก URL ทำงานเสร็จและ cb ถูกเรียกครบแล้ว
+func FetchAllStream(urls []string, cb func(APIResult), opts ...Option) {
+	NewFetcher(opts...).FetchAllStream(urls, cb)
+}
+
+// FetchAllStream ทำงานเหมือนฟังก์ชัน FetchAllStream แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchAllStream(urls []string, cb func(APIResult)) {
+	resultsChan := make(chan APIResult)
+
+	// รัน dispatch ใน goroutine แยก แล้วปิด channel เมื่อทุก request ทำงานเสร็จ
+	go func() {
+		dispatch(context.Background(), requestsFromURLs(urls), f.cfg, func(_ int, result APIResult) {
+			resultsChan <- result
+		})
+		close(resultsChan)
+	}()
+
+	// วนลูปเรียก cb จนกว่า channel จะถูกปิด
+	for result := range resultsChan {
+		cb(result)
+	}
+}
+
+// Results ดึงข้อมูลจากทุก URL พร้อมกันแล้วคืน iterator ที่ให้ผลลัพธ์ตามลำดับที่ทำงานเสร็จ
+// สำหรับรายการ URL ขนาดใหญ่ที่ไม่ต้องการเก็บผลลัพธ์ทั้งหมดไว้ใน slice:
+//
+//	for result := range goroutine.Results(urls, goroutine.WithConcurrency(10)) {
+//		...
+//	}
+//
+// request เริ่มเมื่อเริ่มวนลูปและผลลัพธ์ไม่ถูกเก็บไว้หลังส่งให้ลูปแล้ว ใช้ WithConcurrency เพื่อจำกัดจำนวน
+// request ที่ทำงานพร้อมกัน ถ้าออกจากลูปก่อน (break) request ที่เหลือจะถูกยกเลิกและรอจนจบก่อนออกจากลูป
+func Results(urls []string, opts ...Option) iter.Seq[APIResult] {
+	return NewFetcher(opts...).Results(urls)
+}
+
+// Results ทำงานเหมือนฟังก์ชัน Results แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) Results(urls []string) iter.Seq[APIResult] {
+	return func(yield func(APIResult) bool) {
+		// request ที่ถูกยกเลิกเพราะออกจากลูปไม่ถูกรายงานโดย WithErrorCallback
+		ctx, cancel := withCancelNotNeeded(context.Background())
+		defer cancel()
+
+		// goroutine ที่ทำเสร็จหลังออกจากลูปแล้วจะไม่รอส่งผลลัพธ์ที่ไม่มีผู้รับ
+		resultsChan := make(chan APIResult)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			dispatch(ctx, requestsFromURLs(urls), f.cfg, func(_ int, result APIResult) {
+				select {
+				case resultsChan <- result:
+				case <-ctx.Done():
+				}
+			})
+		}()
+
+		for {
+			select {
+			case result := <-resultsChan:
+				if !yield(result) {
+					cancel()
+					<-done
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// FetchStreamChan อ่าน URL จาก in ทีละตัวแล้วดึงข้อมูล และส่งผลลัพธ์ออกทาง channel ที่คืนมา
+// ตามลำดับที่ทำงานเสร็จ เหมาะกับกรณีที่ URL ทยอยมาเรื่อยๆ แทนที่จะรู้ทั้งหมดล่วงหน้า
+// channel ที่คืนมาจะถูกปิดเมื่อ in ถูกปิดและทุก request ทำงานเสร็จแล้ว
+//
+// ถ้ากำหนด WithConcurrency(n) จะมี worker n ตัวอ่านจาก in จึงมี request ทำงานพร้อมกันไม่เกิน n
+// และ worker จะไม่อ่าน URL ถัดไปจนกว่าผู้เรียกจะรับผลลัพธ์ก่อนหน้าไป (backpressure)
+// ถ้าไม่กำหนดจะสร้างหนึ่ง goroutine ต่อหนึ่ง URL, ผู้เรียกต้องอ่านผลลัพธ์จนกว่า channel จะถูกปิด
+// ไม่เช่นนั้น goroutine ที่รอส่งผลลัพธ์จะค้างอยู่
+func FetchStreamChan(in <-chan string, opts ...Option) <-chan APIResult {
+	return NewFetcher(opts...).FetchStreamChan(in)
+}
+
+// FetchStreamChan ทำงานเหมือนฟังก์ชัน FetchStreamChan แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchStreamChan(in <-chan string) <-chan APIResult {
+	ctx := context.Background()
+	cfg := f.cfg
+	// rate limiter และ retry budget หนึ่งตัวใช้ร่วมกันตลอดทั้ง stream
+	cfg.limiter = newRateLimiter(cfg.rateLimit)
+	cfg.retryBudget = newRetryBudget(cfg.retryBudgetSize)
+
+	out := make(chan APIResult)
+	var wg sync.WaitGroup
+	if cfg.maxWorkers > 0 {
+		// worker จำนวนคงที่อ่าน URL จาก in โดยตรง
+		wg.Add(cfg.maxWorkers)
+		for i := 0; i < cfg.maxWorkers; i++ {
+			go func() {
+				defer wg.Done()
+				for url := range in {
+					out <- fetch(ctx, Request{URL: url}, cfg)
+				}
+			}()
+		}
+	} else {
+		// goroutine หนึ่งตัวอ่าน in แล้วเริ่ม goroutine ใหม่ต่อหนึ่ง URL
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range in {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					out <- fetch(ctx, Request{URL: url}, cfg)
+				}()
+			}
+		}()
+	}
+
+	// ปิด channel เมื่อ in ถูกปิดและทุก request ส่งผลลัพธ์แล้ว
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
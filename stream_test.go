@@ -0,0 +1,43 @@
+package goroutine
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ออกจากลูปของ Results ก่อนครบจะยกเลิก request ที่เหลือโดยไม่รายงานเป็นความล้มเหลว
+func TestResultsBreakCancelsRemaining(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var errs errorRecorder
+	f := NewFetcher(WithErrorCallback(errs.record))
+	start := time.Now()
+	for result := range f.Results([]string{server.URL + "/fast", server.URL + "/slow"}) {
+		if result.Error != nil {
+			t.Fatalf("first result error: %v", result.Error)
+		}
+		break
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("loop exit took %v, want the slow request cancelled", elapsed)
+	}
+	if err := f.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown error: %v", err)
+	}
+	if n := errs.count(); n != 0 {
+		t.Errorf("error callback called %d times, want 0", n)
+	}
+}
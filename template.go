@@ -0,0 +1,68 @@
+package goroutine
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ExpandURL แทน placeholder รูปแบบ {name} ใน template ด้วยค่าจาก params ที่ escape เป็น path segment แล้ว
+// เช่น ExpandURL("https://api.example.com/users/{id}", map[string]string{"id": "a/b"})
+// ได้ "https://api.example.com/users/a%2Fb" ใช้สำหรับ path เท่านั้น ค่าของ query ควรกำหนดผ่าน Request.Query
+// คืน error ถ้า placeholder ไม่มีค่าใน params หรือวงเล็บไม่ครบคู่
+func ExpandURL(template string, params map[string]string) (string, error) {
+	var sb strings.Builder
+	rest := template
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			sb.WriteString(rest)
+			return sb.String(), nil
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed placeholder in URL template %q", template)
+		}
+		name := rest[open+1 : open+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing value for placeholder {%s} in URL template %q", name, template)
+		}
+		sb.WriteString(rest[:open])
+		sb.WriteString(url.PathEscape(value))
+		rest = rest[open+end+1:]
+	}
+}
+
+// FetchTemplate สร้าง URL จาก template ด้วย params แต่ละชุดผ่าน ExpandURL แล้วดึงข้อมูลทั้งหมดพร้อมกัน
+// เช่น ดึง "/users/{id}" ของหลาย id โดยไม่ต้องต่อ string เอง ผลลัพธ์ตำแหน่ง i มาจาก params[i] เสมอ
+// ชุดที่สร้าง URL ไม่ได้จะได้ Error ที่ตำแหน่งนั้น (URL เป็น template) โดยไม่ส่ง request
+func FetchTemplate(template string, params []map[string]string, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchTemplate(template, params)
+}
+
+// FetchTemplate ทำงานเหมือนฟังก์ชัน FetchTemplate แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchTemplate(template string, params []map[string]string) []APIResult {
+	results := make([]APIResult, len(params))
+	// positions[j] คือตำแหน่งใน params ของ urls[j]
+	var (
+		urls      []string
+		positions []int
+	)
+	for i, p := range params {
+		u, err := ExpandURL(template, p)
+		if err != nil {
+			results[i] = APIResult{URL: template, Error: err}
+			continue
+		}
+		urls = append(urls, u)
+		positions = append(positions, i)
+	}
+
+	// ผลลัพธ์แต่ละตัวมี Error ของตัวเองอยู่แล้ว จึงไม่ใช้ error รวมของ FetchAll
+	fetched, _ := f.FetchAll(urls)
+	for j, pos := range positions {
+		results[pos] = fetched[j]
+	}
+	return results
+}
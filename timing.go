@@ -0,0 +1,76 @@
+package goroutine
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing แยกเวลาของแต่ละช่วงใน request ครั้งสุดท้าย เมื่อใช้ WithDetailedTiming
+// ช่วงที่ไม่เกิดขึ้นเป็น 0 เช่น DNSLookup, Connect และ TLSHandshake เมื่อ reuse connection เดิม
+type Timing struct {
+	DNSLookup       time.Duration // เวลาค้นหา DNS
+	Connect         time.Duration // เวลาเชื่อมต่อ TCP
+	TLSHandshake    time.Duration // เวลา TLS handshake
+	TimeToFirstByte time.Duration // เวลาตั้งแต่เริ่มส่ง request (StartTime) จนได้รับ byte แรกของ response
+}
+
+// WithDetailedTiming เก็บเวลาของ DNS, การเชื่อมต่อ, TLS และ byte แรกของ response ผ่าน net/http/httptrace
+// ไว้ใน APIResult.Timing เพื่อวิเคราะห์ว่า request ที่ช้าเสียเวลาไปที่ช่วงใด
+// ปิดไว้เป็นค่าเริ่มต้นเพราะเพิ่มภาระในทุก request
+func WithDetailedTiming() Option {
+	return func(c *config) {
+		c.detailedTiming = true
+	}
+}
+
+// timingTrace เก็บเวลาจาก callback ของ httptrace ซึ่งอาจถูกเรียกจากหลาย goroutine
+// (เช่น การเชื่อมต่อหลาย address พร้อมกัน) จึงใช้ mutex ป้องกัน
+type timingTrace struct {
+	mu        sync.Mutex
+	start     time.Time
+	dnsStart  time.Time
+	connStart time.Time
+	tlsStart  time.Time
+	timing    Timing
+}
+
+// withTrace คืน ctx ที่ติดตามเวลาของ request ที่เริ่มส่งเวลา start ไว้ใน t
+func (t *timingTrace) withTrace(ctx context.Context, start time.Time) context.Context {
+	t.start = start
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.since(&t.dnsStart, &t.timing.DNSLookup) },
+		ConnectStart:         func(string, string) { t.mark(&t.connStart) },
+		ConnectDone:          func(string, string, error) { t.since(&t.connStart, &t.timing.Connect) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.since(&t.tlsStart, &t.timing.TLSHandshake) },
+		GotFirstResponseByte: func() { t.since(&t.start, &t.timing.TimeToFirstByte) },
+	})
+}
+
+// mark บันทึกเวลาปัจจุบันลง at
+func (t *timingTrace) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = time.Now()
+}
+
+// since บันทึกเวลาที่ผ่านไปตั้งแต่ start ลง d
+func (t *timingTrace) since(start *time.Time, d *time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*d = time.Since(*start)
+}
+
+// result คืน Timing ที่เก็บได้จนถึงตอนนี้ ถ้า t เป็น nil จะคืนค่าว่าง
+func (t *timingTrace) result() Timing {
+	if t == nil {
+		return Timing{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timing
+}
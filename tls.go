@@ -0,0 +1,59 @@
+package goroutine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+)
+
+// WithInsecureSkipVerify ปิดการตรวจสอบ certificate ของ server (ยอมรับ self-signed certificate)
+// ใช้สำหรับทดสอบกับ host ภายในเท่านั้น ไม่ควรใช้ใน production เพราะเสี่ยงต่อการถูกดักข้อมูล
+// NewFetcher จะส่ง EventWarning ให้ logger ที่กำหนดผ่าน WithLogger เพื่อเตือนทุกครั้งที่เปิดใช้
+func WithInsecureSkipVerify() Option {
+	return func(c *config) {
+		c.insecureSkipVerify = true
+		withTransport(func(t *http.Transport) {
+			tlsConfig(t).InsecureSkipVerify = true
+		})(c)
+	}
+}
+
+// WithRootCAs ตรวจสอบ certificate ของ server ด้วย pool แทน root CA ของระบบ
+// เช่น เพื่อเชื่อถือ certificate ที่ออกโดย CA ภายในองค์กรโดยไม่ต้องปิดการตรวจสอบ
+// ถ้าใช้ร่วมกับ WithInsecureSkipVerify จะไม่ตรวจสอบ certificate เลย (pool ไม่มีผล) และเตือนผ่าน logger
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *config) {
+		c.rootCAs = true
+		withTransport(func(t *http.Transport) {
+			tlsConfig(t).RootCAs = pool
+		})(c)
+	}
+}
+
+// WithMinTLSVersion ปฏิเสธ server ที่เจรจา TLS ต่ำกว่า v (เช่น tls.VersionTLS12 หรือ tls.VersionTLS13)
+// ตามนโยบายความปลอดภัยขององค์กร การเชื่อมต่อที่ไม่ผ่านจะได้ APIResult.Error ที่บอกว่า protocol version ไม่ตรง
+// ถ้าไม่กำหนดจะใช้ค่าเริ่มต้นของ Go (ปัจจุบันคือ TLS 1.2 สำหรับ client)
+func WithMinTLSVersion(v uint16) Option {
+	return withTransport(func(t *http.Transport) {
+		tlsConfig(t).MinVersion = v
+	})
+}
+
+// tlsConfig คืน TLSClientConfig ของ t โดยสร้างให้ถ้ายังไม่มี
+func tlsConfig(t *http.Transport) *tls.Config {
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	return t.TLSClientConfig
+}
+
+// warnTLS เตือนผ่าน logger เมื่อการตั้งค่า TLS ไม่ปลอดภัย ถูกเรียกครั้งเดียวใน NewFetcher
+func warnTLS(cfg config) {
+	if cfg.insecureSkipVerify {
+		cfg.log(EventWarning, APIResult{Error: errors.New("TLS certificate verification is disabled (WithInsecureSkipVerify)")})
+	}
+	if cfg.insecureSkipVerify && cfg.rootCAs {
+		cfg.log(EventWarning, APIResult{Error: errors.New("WithRootCAs is ignored because WithInsecureSkipVerify disables certificate verification")})
+	}
+}
@@ -0,0 +1,37 @@
+package goroutine
+
+import (
+	"crypto/tls"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// tlsServer สร้าง server ที่รองรับ TLS ในช่วง minVersion ถึง maxVersion เท่านั้น
+func tlsServer(minVersion, maxVersion uint16) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{MinVersion: minVersion, MaxVersion: maxVersion}
+	// ไม่ต้องแสดง log ของ handshake ที่ล้มเหลวตามที่ test ตั้งใจ
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	return server
+}
+
+// server ที่รองรับสูงสุด TLS 1.2 ใช้ได้ตามค่าเริ่มต้นของ Go แต่ถูกปฏิเสธเมื่อกำหนด WithMinTLSVersion เป็น TLS 1.3
+func TestWithMinTLSVersion(t *testing.T) {
+	server := tlsServer(tls.VersionTLS12, tls.VersionTLS12)
+	defer server.Close()
+
+	if result := Fetch(server.URL, WithClient(server.Client())); result.Error != nil || string(result.Body) != "ok" {
+		t.Fatalf("without WithMinTLSVersion: got error %v, body %q; want body %q", result.Error, result.Body, "ok")
+	}
+	result := Fetch(server.URL, WithClient(server.Client()), WithMinTLSVersion(tls.VersionTLS13))
+	if result.Error == nil || !strings.Contains(result.Error.Error(), "protocol version") {
+		t.Errorf("with TLS 1.3 minimum: error = %v, want TLS protocol version error", result.Error)
+	}
+}
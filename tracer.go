@@ -0,0 +1,49 @@
+package goroutine
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer สร้าง span ของแต่ละ request สำหรับ distributed tracing เช่น OpenTelemetry
+// แพ็กเกจนี้ไม่ผูกกับ library ใดๆ ผู้ใช้ implement interface นี้ด้วย tracer ของตัวเอง เช่น
+// Start เรียก otel Tracer.Start พร้อม attribute ของ url และ Inject เรียก
+// propagator.Inject(ctx, propagation.HeaderCarrier(header))
+// Tracer อาจถูกเรียกพร้อมกันจากหลาย goroutine จึงต้องปลอดภัยสำหรับการใช้งานพร้อมกัน
+type Tracer interface {
+	// Start ถูกเรียกครั้งเดียวต่อหนึ่ง request ก่อนความพยายามครั้งแรก
+	// ctx ที่คืนมาใช้ส่ง request ทุกครั้ง (รวมการลองใหม่) จึงเป็น parent ของ span อื่นใน transport ได้
+	Start(ctx context.Context, url string) (context.Context, Span)
+
+	// Inject ใส่ trace header (เช่น traceparent) ลงใน header ของ request ก่อนส่งทุกครั้ง
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span คือ span ของ request หนึ่งตัวที่ได้จาก Tracer.Start
+type Span interface {
+	// End ถูกเรียกเมื่อ request ทำงานเสร็จ result คือผลลัพธ์สุดท้ายที่มี StatusCode, Error และ Latency
+	End(result APIResult)
+}
+
+// noopTracer คือ Tracer เริ่มต้นที่ไม่ทำอะไรเลย
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+func (noopTracer) Inject(context.Context, http.Header) {}
+
+// noopSpan คือ Span ของ noopTracer
+type noopSpan struct{}
+
+func (noopSpan) End(APIResult) {}
+
+// WithTracer สร้าง span ของทุก request ด้วย t, ถ้าไม่กำหนด (หรือ t เป็น nil) จะไม่สร้าง span
+func WithTracer(t Tracer) Option {
+	return func(c *config) {
+		if t == nil {
+			t = noopTracer{}
+		}
+		c.tracer = t
+	}
+}
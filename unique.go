@@ -0,0 +1,53 @@
+package goroutine
+
+// FetchUnique ทำงานเหมือน FetchAll แต่ URL ที่ซ้ำกันจะถูกดึงเพียงครั้งเดียว
+// แล้วคืนผลลัพธ์ครบทุกตำแหน่งของ urls ตามลำดับเดิม
+//
+// ตำแหน่งที่ URL ซ้ำกันจะได้ผลลัพธ์ชุดเดียวกัน รวมถึง Latency, StartTime และ EndTime
+// ของการดึงครั้งนั้นครั้งเดียว (ไม่ใช่ 0 หรือเวลาที่แยกกัน) และใช้ Body กับ Headers ร่วมกัน
+// จึงไม่ควรแก้ไขค่าเหล่านี้ในผลลัพธ์ตำแหน่งหนึ่งถ้าต้องใช้ตำแหน่งอื่นต่อ
+func FetchUnique(urls []string, opts ...Option) []APIResult {
+	return NewFetcher(opts...).FetchUnique(urls)
+}
+
+// FetchUnique ทำงานเหมือนฟังก์ชัน FetchUnique แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchUnique(urls []string) []APIResult {
+	// positions[i] คือตำแหน่งของ urls[i] ใน unique
+	seen := make(map[string]int, len(urls))
+	positions := make([]int, len(urls))
+	var unique []string
+	for i, url := range urls {
+		pos, ok := seen[url]
+		if !ok {
+			pos = len(unique)
+			seen[url] = pos
+			unique = append(unique, url)
+		}
+		positions[i] = pos
+	}
+
+	// ผลลัพธ์แต่ละตัวมี Error ของตัวเองอยู่แล้ว จึงไม่ใช้ error รวมของ FetchAll
+	fetched, _ := f.FetchAll(unique)
+	results := make([]APIResult, len(urls))
+	for i, pos := range positions {
+		results[i] = fetched[pos]
+	}
+	return results
+}
+
+// FetchAllMap ดึงข้อมูลจากทุก URL พร้อมกันแล้วคืนผลลัพธ์เป็น map ที่ใช้ URL เป็น key
+// URL ที่ซ้ำกันจะถูกดึงเพียงครั้งเดียวเหมือน FetchUnique จึงมีผลลัพธ์เดียวต่อ URL
+// และจำนวนสมาชิกของ map เท่ากับจำนวน URL ที่ไม่ซ้ำกัน
+func FetchAllMap(urls []string, opts ...Option) map[string]APIResult {
+	return NewFetcher(opts...).FetchAllMap(urls)
+}
+
+// FetchAllMap ทำงานเหมือนฟังก์ชัน FetchAllMap แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchAllMap(urls []string) map[string]APIResult {
+	results := f.FetchUnique(urls)
+	byURL := make(map[string]APIResult, len(results))
+	for _, result := range results {
+		byURL[result.URL] = result
+	}
+	return byURL
+}
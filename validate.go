@@ -0,0 +1,33 @@
+package goroutine
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Validate ตรวจ URL แต่ละตัวโดยไม่ส่ง request จริง เพื่อหา URL ที่พิมพ์ผิดก่อนเริ่มชุดใหญ่
+// errs[i] คือ error ของ urls[i] หรือ nil ถ้า URL นั้นสร้าง request ได้
+// นอกจากการตรวจแบบเดียวกับตอนสร้าง request ใน fetchOnce ยังตรวจว่าเป็น http/https และมี host
+// ซึ่งถ้าไม่ครบจะล้มเหลวตอนส่งจริงอยู่ดี
+func Validate(urls []string) []error {
+	errs := make([]error, len(urls))
+	for i, url := range urls {
+		errs[i] = validateURL(url)
+	}
+	return errs
+}
+
+// validateURL ตรวจ URL หนึ่งตัว
+func validateURL(url string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", url, err)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("invalid URL %q: unsupported scheme %q", url, req.URL.Scheme)
+	}
+	if req.URL.Host == "" {
+		return fmt.Errorf("invalid URL %q: missing host", url)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package goroutine
+
+import (
+	"errors"
+	"math/rand/v2"
+)
+
+// WeightedURL คือ URL ของ mirror หนึ่งตัวพร้อมน้ำหนักสำหรับ FetchWeighted
+// Weight เป็นสัดส่วนโอกาสที่จะถูกเลือก เช่น mirror ที่รับโหลดได้สองเท่าให้ Weight เป็นสองเท่า
+type WeightedURL struct {
+	URL    string
+	Weight float64
+}
+
+// FetchWeighted สุ่มเลือก URL หนึ่งตัวจาก mirrors ตามน้ำหนักแล้วดึงข้อมูล เป็น load balancer ฝั่ง client แบบง่าย
+// URL ที่ถูกเลือกอยู่ใน APIResult.URL, mirror ที่น้ำหนักเท่ากันมีโอกาสถูกเลือกเท่ากัน
+// และ mirror ที่ Weight <= 0 จะไม่ถูกเลือก ถ้าไม่มี mirror ที่เลือกได้เลยจะคืน Error โดยไม่ส่ง request
+func FetchWeighted(mirrors []WeightedURL, opts ...Option) APIResult {
+	return NewFetcher(opts...).FetchWeighted(mirrors)
+}
+
+// FetchWeighted ทำงานเหมือนฟังก์ชัน FetchWeighted แต่ใช้การตั้งค่าของ f
+func (f *Fetcher) FetchWeighted(mirrors []WeightedURL) APIResult {
+	url, ok := pickWeighted(mirrors)
+	if !ok {
+		return APIResult{Error: errors.New("no URLs with positive weight to fetch")}
+	}
+	return f.Fetch(url)
+}
+
+// pickWeighted สุ่มเลือก URL จาก mirrors ตามน้ำหนัก คืน false ถ้าไม่มี mirror ที่ Weight > 0
+func pickWeighted(mirrors []WeightedURL) (string, bool) {
+	var total float64
+	for _, m := range mirrors {
+		if m.Weight > 0 {
+			total += m.Weight
+		}
+	}
+	if total <= 0 {
+		return "", false
+	}
+
+	target := rand.Float64() * total
+	var last string
+	for _, m := range mirrors {
+		if m.Weight <= 0 {
+			continue
+		}
+		if target < m.Weight {
+			return m.URL, true
+		}
+		target -= m.Weight
+		last = m.URL
+	}
+	// ความคลาดเคลื่อนของการบวกเลขทศนิยมอาจทำให้ target เหลือเกินเล็กน้อย ให้ใช้ตัวสุดท้าย
+	return last, true
+}